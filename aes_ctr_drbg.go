@@ -25,13 +25,14 @@ package ctrdrbg
 import (
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/rand"
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math/bits"
 	mrand "math/rand/v2"
-	"os"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,6 +41,10 @@ import (
 const (
 	// MaxBytesPerRequest is the NIST SP 800-90A maximum bytes per request for CTR_DRBG (2^19 bits = 64 KB).
 	MaxBytesPerRequest = 1 << 16
+
+	// ctrStreamMinBytes is the smallest request for which fillBlocks creates a new multi-block CTR stream.
+	// Smaller requests without a positioned stream are generated block by block.
+	ctrStreamMinBytes = 128
 )
 
 var (
@@ -48,13 +53,20 @@ var (
 
 	// ErrHealthTestFailed indicates that the continuous health test detected stuck output.
 	ErrHealthTestFailed = errors.New("ctrdrbg: continuous health test failed (stuck output detected)")
+
+	// ErrClosed is returned by a Reader (and its Sessions) after Close.
+	ErrClosed = errors.New("ctrdrbg: reader closed")
 )
 
 // Reader is a package-level, cryptographically secure random source suitable for high-concurrency applications.
 //
-// Reader is initialized at package load time via NewReader and is safe for concurrent use. If initialization fails
-// (for example, if crypto/rand is unavailable), the package will panic. This ensures that any failure to obtain a secure
-// entropy source is detected immediately and not silently ignored.
+// Reader is initialized with the default configuration on first use and is safe for concurrent use. Importing the
+// package never panics: if initialization fails (for example, if crypto/rand is unavailable), the failing call returns
+// the error, typically an *InstantiationError, and the next call retries. No output is ever produced without a
+// successfully seeded generator.
+//
+// Reader is declared as Interface, so it can be reseeded, closed, or inspected directly, for example with
+// Reader.Stats() or Reader.ComplianceReport().
 //
 // Example usage:
 //
@@ -64,7 +76,7 @@ var (
 //	    // handle error
 //	}
 //	fmt.Printf("Random data: %x\n", buf)
-var Reader io.Reader
+var Reader Interface = &lazyReader{}
 
 // Interface defines the contract for a NIST SP 800-90A AES-CTR-DRBG random source.
 //
@@ -100,76 +112,169 @@ type Interface interface {
 	//
 	// Returns the number of bytes read (equal to len(b)) and an error (if any).
 	ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, error)
+
+	// Acquire returns a Session holding one DRBG instance exclusively until Session.Release,
+	// for callers that issue many consecutive reads.
+	//
+	// Returns an error if a new instance is needed and cannot be instantiated.
+	Acquire() (Session, error)
+
+	// Stats returns a snapshot of the reader's activity counters, per shard and in total. It adds no
+	// synchronization to the read path and may be called at any time, including after Close. It briefly
+	// holds each shard's pool lock, which requests take only to create or retire an instance or to apply
+	// a pending Reseed.
+	Stats() Stats
+
+	// ComplianceReport evaluates the reader's configuration against the SP 800-90A, SP 800-90C, and
+	// FIPS 140-3 requirements that depend on configuration or runtime state (see EvaluateCompliance).
+	ComplianceReport() ComplianceReport
+
+	// Close uninstantiates every DRBG instance (NIST SP 800-90A §9.4): it waits for pending background
+	// rekeys and zeroizes each instance's key, counter (V), and buffers. Instances in use when Close is
+	// called, including those held by a Session, are zeroized when they are returned.
+	//
+	// After Close, every method except Config, Stats, and ComplianceReport returns ErrClosed. Close is idempotent.
+	Close() error
 }
 
-// init initializes the package-level Reader. It panics if NewReader fails, preventing operation without
-// a secure random source. This follows cryptographic best practices by making entropy failure a fatal error.
-func init() {
-	cfg := DefaultConfig()
-	pools, err := initShardPools(cfg)
+// lazyReader is the package-level Reader. It constructs a Reader from opts (the defaults, for the package
+// Reader) on first use, retrying on every call until construction succeeds, so that initialization
+// failures surface as errors rather than panics.
+type lazyReader struct {
+	opts []Option
+
+	r  atomic.Pointer[reader]
+	mu sync.Mutex
+
+	// closed is set if Close is called before the Reader is constructed. It is guarded by mu.
+	closed bool
+}
+
+// get returns the underlying Reader, constructing it if needed.
+func (l *lazyReader) get() (*reader, error) {
+	if r := l.r.Load(); r != nil {
+		return r, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r := l.r.Load(); r != nil {
+		return r, nil
+	}
+	if l.closed {
+		return nil, ErrClosed
+	}
+	rdr, err := NewReader(l.opts...)
 	if err != nil {
-		panic(fmt.Sprintf("ctrdrbg: failed to initialize pools: %v", err))
+		return nil, err
 	}
+	r := rdr.(*reader)
+	l.r.Store(r)
+	return r, nil
+}
 
-	Reader = &reader{pools: pools}
+// Read implements io.Reader using the default Reader.
+func (l *lazyReader) Read(b []byte) (int, error) {
+	r, err := l.get()
+	if err != nil {
+		return 0, err
+	}
+	return r.Read(b)
 }
 
-// initShardPools creates and validates all sync.Pool shards for concurrent DRBG use.
-//
-// For each shard, a sync.Pool is created whose New function constructs a DRBG instance using the provided config.
-// If instantiation fails, it retries up to MaxInitRetries times, then panics if unsuccessful.
-// After creating each pool, it is eagerly tested by borrowing and returning an instance, to ensure failures are
-// caught at construction rather than at first use.
+// ReadWithAdditionalInput implements Interface using the default Reader.
+func (l *lazyReader) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, error) {
+	r, err := l.get()
+	if err != nil {
+		return 0, err
+	}
+	return r.ReadWithAdditionalInput(b, additionalInput)
+}
+
+// Reseed implements Interface using the default Reader.
+func (l *lazyReader) Reseed(additionalInput []byte) error {
+	r, err := l.get()
+	if err != nil {
+		return err
+	}
+	return r.Reseed(additionalInput)
+}
+
+// Acquire implements Interface using the default Reader.
+func (l *lazyReader) Acquire() (Session, error) {
+	r, err := l.get()
+	if err != nil {
+		return Session{}, err
+	}
+	return r.Acquire()
+}
+
+// Stats returns the counters of the default Reader, or zero counters if it has not been initialized yet.
+func (l *lazyReader) Stats() Stats {
+	if r := l.r.Load(); r != nil {
+		return r.Stats()
+	}
+	return Stats{}
+}
+
+// Config returns the configuration of the default Reader, which is DefaultConfig.
+func (l *lazyReader) Config() Config {
+	if r := l.r.Load(); r != nil {
+		return r.Config()
+	}
+	return DefaultConfig()
+}
+
+// ComplianceReport evaluates the configuration of the default Reader. It does not initialize the Reader.
+func (l *lazyReader) ComplianceReport() ComplianceReport {
+	return EvaluateCompliance(l.Config())
+}
+
+// Close closes the default Reader. If it has not been initialized yet, it never will be. Either way, its
+// methods subsequently return ErrClosed.
+func (l *lazyReader) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+
+	if r := l.r.Load(); r != nil {
+		return r.Close()
+	}
+	return nil
+}
+
+// initShardPools creates and validates the instance pool of every shard.
 //
-// If any pool.New panics (due to repeated DRBG initialization failure), the function recovers and returns the
-// error to the caller (for use in NewReader). In package-level init, a panic is allowed to abort process startup.
+// Each shard gets its own copy of cfg (and entropy prefetch buffer, if enabled), shared by the instances of
+// that shard. Each pool is eagerly tested by constructing its first instance, retrying up to MaxInitRetries
+// times, to ensure failures are caught at construction rather than at first use.
 //
 // Parameters:
 //   - cfg: Config specifying DRBG options (shard count, retries, etc.)
 //
 // Returns:
-//   - []*sync.Pool: slice of initialized pools, one per shard.
-//   - error: non-nil if pool initialization panicked for any shard.
-func initShardPools(cfg Config) ([]*sync.Pool, error) {
-	// Create a slice of sync.Pool pointers, one for each shard.
-	pools := make([]*sync.Pool, cfg.Shards)
+//   - []*instancePool: slice of initialized pools, one per shard.
+//   - error: non-nil if the first instance of any shard could not be constructed.
+func initShardPools(cfg Config) ([]*instancePool, error) {
+	pools := make([]*instancePool, cfg.Shards)
 	for i := range pools {
-		// Capture the config by value for use in the pool.New closure (avoids loop variable capture bug).
-		capturedCfg := cfg
-		pools[i] = &sync.Pool{
-			New: func() interface{} {
-				var d *drbg
-				var err error
-				// Attempt to instantiate a DRBG instance, retrying up to MaxInitRetries times.
-				for r := 0; r < capturedCfg.MaxInitRetries; r++ {
-					if d, err = newDRBG(&capturedCfg); err == nil {
-						return d
-					}
-				}
-				// If all attempts fail here, we return nil. The eager initialization below
-				// performs the same construction and will surface a concrete error to the caller.
-				return nil
-			},
-		}
+		// Give each shard its own copy of the config.
+		shardCfg := cfg
+		shardCfg.shard = i
 
-		// Eagerly test pool initialization to ensure catastrophic failures are caught immediately,
-		// not deferred until first use. Attempt construction directly and return an error on failure.
-		var (
-			warm *drbg
-			err  error
-		)
-		for r := 0; r < capturedCfg.MaxInitRetries; r++ {
-			if warm, err = newDRBG(&capturedCfg); err == nil {
-				pools[i].Put(warm)
-				err = nil
-				break
-			}
+		// Give each shard its own entropy prefetch buffer, if enabled.
+		if shardCfg.EntropyPrefetchSize > 0 {
+			shardCfg.prefetch = newEntropyPrefetcher(&shardCfg)
 		}
+		pools[i] = newInstancePool(&shardCfg)
 
-		// If initialization failed, return error immediately.
+		// Eagerly construct the first instance so that catastrophic failures are caught immediately,
+		// not deferred until first use.
+		warm, err := pools[i].get()
 		if err != nil {
-			return nil, fmt.Errorf("ctrdrbg pool initialization failed after %d retries: %v", capturedCfg.MaxInitRetries, err)
+			return nil, err
 		}
+		pools[i].put(warm)
 	}
 
 	return pools, nil
@@ -178,15 +283,29 @@ func initShardPools(cfg Config) ([]*sync.Pool, error) {
 // reader is an internal implementation of io.Reader that uses a pool of DRBG instances
 // to support efficient concurrent random byte generation.
 type reader struct {
-	pools []*sync.Pool
+	pools []*instancePool
+
+	// strategy selects how shards are chosen for each request (see ShardStrategy).
+	// The zero value is ShardStrategyAffinity.
+	strategy ShardStrategy
+
+	// next is the shared counter used for round-robin selection and for assigning affinity hints.
+	next atomic.Uint64
+
+	// hints caches one shardHint per processor (P) for ShardStrategyAffinity.
+	hints sync.Pool
+
+	// expvar is the binding publishing the reader's Stats, if ExpvarName is set. Guarded by expvarMu.
+	expvar *expvarBinding
 }
 
 // NewReader constructs and returns an io.Reader that produces cryptographically secure
 // random bytes using a pool of AES-CTR-DRBG instances. Functional options may be supplied to customize key size,
-// key rotation, and pool behavior. Each generator is seeded with entropy from crypto/rand.
+// key rotation, and pool behavior. Each generator is seeded from the configured EntropySource (crypto/rand by default).
 //
 // The returned Reader is safe for concurrent use. If no generator can be created after MaxInitRetries,
-// NewReader returns an error.
+// NewReader returns an error. If EntropyTimeout is set and the entropy source is not ready in time,
+// NewReader returns an *EntropyNotReadyError.
 //
 // Example:
 //
@@ -208,23 +327,39 @@ func NewReader(opts ...Option) (Interface, error) {
 		opt(&cfg)
 	}
 
+	// Reject invalid settings before running self-tests or touching the entropy source.
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Shards == 0 {
+		cfg.Shards = runtime.GOMAXPROCS(0)
+	}
+
 	// FIPS 140-2 §4.9.1: Run Known Answer Tests if enabled.
 	if cfg.EnableSelfTests {
-		if err := RunSelfTests(); err != nil {
+		start := time.Now()
+		err := RunSelfTests()
+		cfg.selfTest = &SelfTestEvent{Duration: time.Since(start), Err: err}
+		selfTestEvent(&cfg, cfg.selfTest.Duration, err)
+		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Validate the configured key size is appropriate for AES.
-	// Only 16, 24, or 32 bytes (AES-128, AES-192, AES-256) are supported.
-	switch cfg.KeySize {
-	case KeySize128, KeySize192, KeySize256:
-	default:
-		return nil, fmt.Errorf("invalid key size %d bytes; must be 16, 24, or 32", cfg.KeySize)
+	// Wait (bounded by EntropyTimeout) for the entropy source to become ready, rather than
+	// blocking indefinitely in the first seed read when the kernel CRNG is not yet initialized.
+	if err := waitForEntropy(cfg.entropySource(), cfg.EntropyTimeout); err != nil {
+		return nil, err
 	}
 
-	if cfg.MaxInitRetries < 1 {
-		return nil, fmt.Errorf("invalid MaxInitRetries: must be >= 1")
+	// Track the VM generation ID, if configured, so that every instance reseeds after a snapshot
+	// restore or clone.
+	if cfg.GenerationIDProvider != nil {
+		w, err := newGenerationWatcher(cfg.GenerationIDProvider, cfg.GenerationIDCheckInterval)
+		if err != nil {
+			return nil, err
+		}
+		cfg.generation = w
 	}
 
 	// Initialize the shard pools using the validated configuration.
@@ -234,7 +369,16 @@ func NewReader(opts ...Option) (Interface, error) {
 	}
 
 	//  Return a new reader that wraps the initialized pool.
-	return &reader{pools: pools}, nil
+	r := &reader{pools: pools, strategy: cfg.ShardStrategy}
+
+	// Publish the reader's Stats to expvar, if requested.
+	if cfg.ExpvarName != "" {
+		if err := publishExpvar(cfg.ExpvarName, r); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
 }
 
 // Config returns a copy of the deterministic random bit generator’s static configuration.
@@ -243,10 +387,10 @@ func NewReader(opts ...Option) (Interface, error) {
 // No secret key material, runtime state, or internal DRBG details are included in the result.
 // The returned Config is a copy and safe for inspection or serialization.
 func (r *reader) Config() Config {
-	// It's safe to fetch from any pool, as all configs are the same.
-	d := r.pools[0].Get().(*drbg)
-	cfg := *d.config
-	r.pools[0].Put(d)
+	// It's safe to read from any pool, as all configs are the same.
+	cfg := *r.pools[0].cfg
+	cfg.generation = nil
+	cfg.prefetch = nil
 	return cfg
 }
 
@@ -264,10 +408,12 @@ func (r *reader) Config() Config {
 //
 // Returns:
 //   - error: Returns the first error encountered if any DRBG instance fails to reseed; otherwise returns nil.
+//     Every other instance reseeds before its next output, and that output fails if its reseed fails.
 //
 // Security and Compliance Notes:
 //   - Reseed is safe for concurrent use and may be called at any time during operation.
-//   - If called while DRBGs are in use, subsequent reads immediately begin using the newly seeded state.
+//   - Every live instance is reseeded, idle or in use (including those held by a Session), before its next
+//     output. Once Reseed has returned, no new request is served from a pre-Reseed state.
 //   - This is required for some FIPS and high-assurance applications and is recommended for recovery from suspected
 //     entropy pool compromise or for regulatory compliance triggers.
 //
@@ -278,29 +424,47 @@ func (r *reader) Config() Config {
 //	    log.Fatalf("reseed failed: %v", err)
 //	}
 func (r *reader) Reseed(additionalInput []byte) error {
-	// Iterate over each sync.Pool in the shard pool array.
+	var first error
 	for _, pool := range r.pools {
-		// Borrow a DRBG instance from the pool.
-		d := pool.Get().(*drbg)
-		// Attempt to reseed this DRBG instance using the provided additionalInput.
-		// Reseed will combine system entropy, personalization, and additionalInput as per NIST.
-		err := d.Reseed(additionalInput)
-		// Return the DRBG instance to the pool for future reuse, regardless of success.
-		pool.Put(d)
-		// If reseed fails for any DRBG, immediately return the error to the caller.
-		if err != nil {
-			return err
+		// Reseed every instance of the shard. Each reseed combines system entropy, personalization,
+		// and additionalInput as per NIST.
+		if err := pool.reseed(additionalInput); err != nil && first == nil {
+			first = err
 		}
 	}
-	// If all DRBGs reseeded successfully, return nil.
+	return first
+}
+
+// Close uninstantiates every DRBG instance of every shard, per NIST SP 800-90A §9.4.
+//
+// Close waits for pending background rekeys and zeroizes the key, counter (V), keystream cache, and seed
+// buffers of every idle instance, along with each shard's entropy prefetch buffer. Instances in use when
+// Close is called, including those held by a Session, are zeroized when they are returned to the pool.
+//
+// After Close, Read, ReadWithAdditionalInput, Reseed, and Acquire return ErrClosed, as do reads through
+// existing Sessions. The name published with ExpvarName reports null and may be reused. Close is idempotent
+// and always returns nil.
+//
+// Example usage:
+//
+//	r, err := ctrdrbg.NewReader()
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer r.Close()
+func (r *reader) Close() error {
+	unpublishExpvar(r)
+	for _, pool := range r.pools {
+		pool.close()
+	}
 	return nil
 }
 
 // shardIndex selects a pseudo-random shard index in the range [0, n) using
 // a fast, thread-safe global PCG64-based RNG.
 //
-// This function is used to evenly distribute load across multiple sync.Pool
-// shards, reducing contention in high-concurrency scenarios. It avoids the
+// This function implements ShardStrategyRandom, distributing load across multiple sync.Pool
+// shards to reduce contention in high-concurrency scenarios. It avoids the
 // overhead of time-based seeding or mutex contention.
 //
 // The randomness is not cryptographically secure but is safe for concurrent
@@ -344,17 +508,15 @@ func shardIndex(n int) int {
 //	    // handle error
 //	}
 func (r *reader) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, error) {
-	// Determine the number of pools (shards) in the reader for load balancing.
-	n := len(r.pools)
-	shard := 0
-	// For multiple shards, select a random shard index for this call.
-	if n > 1 {
-		shard = shardIndex(n)
-	}
+	// Select a shard for this call according to the configured ShardStrategy.
+	pool := r.pools[r.shard()]
 	// Borrow a DRBG instance from the selected pool for this operation.
-	d := r.pools[shard].Get().(*drbg)
+	d, err := pool.get()
+	if err != nil {
+		return 0, err
+	}
 	// Ensure the instance is returned to the pool after use (even on error or panic).
-	defer r.pools[shard].Put(d)
+	defer pool.put(d)
 	// Fill the buffer using the borrowed DRBG, injecting additionalInput as specified.
 	return d.ReadWithAdditionalInput(b, additionalInput)
 }
@@ -378,28 +540,30 @@ func (r *reader) Read(b []byte) (int, error) {
 		return 0, nil
 	}
 
-	// Determine the shard index based on the number of pools available.
-	n := len(r.pools)
-	shard := 0
-	if n > 1 {
-		shard = shardIndex(n)
-	}
+	// Select a shard for this call according to the configured ShardStrategy.
+	pool := r.pools[r.shard()]
 
 	// Borrow an instance of the internal deterministic random bit generator from the pool.
 	// This ensures that each call gets exclusive access to an isolated state for cryptographic safety.
-	d := r.pools[shard].Get().(*drbg)
+	d, err := pool.get()
+	if err != nil {
+		return 0, err
+	}
 
 	// Ensure that the borrowed instance is returned to the pool, even if Read fails or panics.
 	// This pattern prevents resource leaks and maintains pool integrity.
-	defer r.pools[shard].Put(d)
+	defer pool.put(d)
 
 	// Fill the caller’s buffer with random data using the borrowed generator.
 	// The actual cryptographic work is performed by the internal generator’s Read method.
 	return d.Read(b)
 }
 
-// state encapsulates the immutable cryptographic state of the DRBG, excluding the counter.
-// This state is swapped atomically on rekey.
+// state encapsulates the cryptographic state of the DRBG, excluding the counter.
+// This state is replaced on reseed and rekey.
+//
+// Each instance owns two state slots and alternates between them on reseed and rekey (see installSeed).
+// A slot is only rewritten by the owning goroutine while it is not current.
 type state struct {
 	// block is the initialized AES cipher.Block used in CTR mode.
 	//
@@ -428,13 +592,13 @@ type state struct {
 // drbg represents an internal deterministic random bit generator (DRBG) implementing
 // the io.Reader interface using the NIST SP 800-90A AES-CTR-DRBG construction.
 //
-// Each drbg instance is intended to be used by a single goroutine at a time and is not
-// safe for concurrent use. It maintains its own AES cipher, secret key, counter, usage counter,
-// and rekeying flag for key rotation.
+// Each drbg instance is owned by a single goroutine at a time (the Reader hands instances out
+// exclusively from its pools) and is not safe for concurrent use. It maintains its own AES cipher,
+// secret key, counter, usage counter, and rekeying flag for key rotation.
 //
-// This implementation ensures FIPS 140-2 alignment, strong security, and high performance
-// under concurrent workloads by separating immutable cryptographic state (managed atomically)
-// from the evolving counter (protected by a mutex).
+// Only the owner reads or replaces the cryptographic state, so the generate path takes no locks
+// and performs no atomic operations. Background key rotation (asyncRekey) only prepares seed
+// material; the owner installs it at its next request (see applyRekey).
 type drbg struct {
 	// config holds the immutable configuration for this DRBG instance.
 	//
@@ -445,16 +609,16 @@ type drbg struct {
 	// - Pool initialization and retry settings
 	config *Config
 
-	// state is an atomic pointer to the immutable cryptographic state for this DRBG.
+	// state points to the current cryptographic state for this DRBG, one of slots.
 	//
 	// This state includes:
 	//   - AES block cipher (used in CTR mode)
 	//   - Secret key material
 	//   - Initial counter value (NIST "V") at creation or rekey
 	//
-	// The atomic pointer allows for fast, race-free swapping of key/counter/cipher state
-	// during asynchronous rekeying, without impacting ongoing read operations.
-	state atomic.Pointer[state]
+	// It is only read and replaced by the owning goroutine, including when a background rekey
+	// completes, so no synchronization is required.
+	state *state
 
 	// lastReseedTime records the time of the last successful reseed.
 	// Used to determine if the configured ReseedInterval has elapsed and
@@ -467,13 +631,22 @@ type drbg struct {
 	// AES-CTR output to efficiently produce random bytes. Sized dynamically as needed.
 	zero []byte
 
-	// vMu is a mutex protecting the evolving counter (v) for this DRBG instance.
-	//
-	// All access and mutation of v must occur with this mutex held to ensure:
-	//   - Counter advancement is atomic and non-overlapping across reads
-	//   - Proper persistence of the counter-value between consecutive reads
-	//   - Safe resetting of the counter during key rotation (rekey)
-	vMu sync.Mutex
+	// slots are the two state buffers alternately filled by installSeed, so reseeding reuses memory
+	// instead of allocating a new state. The current slot is published through the state pointer.
+	slots [2]state
+
+	// reseedBuf is the seed working buffer for instantiation, reseed, and update, which run on the
+	// goroutine that owns the instance.
+	reseedBuf seedScratch
+
+	// rekeyBuf is the seed working buffer for the asynchronous rekey goroutine. While a rekey is in
+	// flight it belongs to that goroutine; once rekeyResult reports rekeyReady it holds the prepared
+	// seed and belongs to the owner again.
+	rekeyBuf seedScratch
+
+	// xorBuf is a reusable scratch buffer holding entropy source output for the RBG3(XOR) construction.
+	// It is cleared after every use.
+	xorBuf []byte
 
 	// v is the current 128-bit internal counter (NIST "V") for the DRBG instance.
 	//
@@ -493,10 +666,35 @@ type drbg struct {
 	encV [16]byte
 
 	// tmp is a persistent [16]byte working buffer used during output generation.
-	// It holds the encrypted output for the final (partial) block in fillBlocks.
+	// It absorbs the discarded keystream of the final (partial) block in fillBlocks.
 	// This avoids repeated stack allocations and ensures maximum efficiency.
 	tmp [16]byte
 
+	// ctr is the cached multi-block AES-CTR keystream used by fillBlocks, so bulk output comes from
+	// the standard library's pipelined (AES-NI / ARMv8) implementation rather than per-block Encrypt calls.
+	//
+	// It is positioned at the block following ctrV under ctrState, and is only reused when both match
+	// the request's state and counter; otherwise a new stream is created.
+	ctr cipher.Stream
+
+	// ctrState is the state whose cipher produced ctr.
+	ctrState *state
+
+	// ctrV is the counter value (V) corresponding to ctr's current position.
+	ctrV [16]byte
+
+	// poolEpoch is the epoch of the owning instancePool at this instance's last pool-wide reseed.
+	poolEpoch uint64
+
+	// cache holds pre-generated keystream for small reads when KeystreamCacheSize is set; nil otherwise.
+	//
+	// Bytes before cacheOff have been served and zeroized; bytes from cacheOff to len(cache) are unread.
+	// The cache is wiped whenever the state is replaced (reseed, rekey, fork).
+	cache []byte
+
+	// cacheOff is the offset of the next unread byte in cache.
+	cacheOff int
+
 	// Previous output block for continuous health test
 	lastOutputBlock [16]byte
 
@@ -508,18 +706,18 @@ type drbg struct {
 	// usage tracks the number of bytes generated since the last key rotation.
 	//
 	// When usage exceeds config.MaxBytesPerKey, a rekey is triggered to ensure
-	// forward secrecy and mitigate key compromise risk. This value is atomically updated.
+	// forward secrecy and mitigate key compromise risk.
 	usage uint64
 
-	// pid caches the process identifier (PID) of the operating system process in which
-	// this DRBG instance was most recently initialized or reseeded.
+	// forkEpoch caches the process fork epoch (see processEpoch) observed when this DRBG instance was
+	// most recently initialized or checked for a fork.
 	//
 	// Purpose:
 	//   - Enables robust detection of process-level forks (e.g., via fork(2) or similar system calls).
-	//   - After a fork, the child process receives a new, unique PID, but the DRBG instance initially
-	//     retains the PID from its parent process.
-	//   - By comparing the current process PID (os.Getpid()) to this cached value, the DRBG can reliably
-	//     detect fork events at runtime.
+	//   - After a fork, the child process observes a new epoch, but the DRBG instance initially retains
+	//     the epoch cached in its parent process.
+	//   - On Linux 4.14+, the epoch advances when a MADV_WIPEONFORK sentinel page is found zeroed, which
+	//     costs a single memory load per check; elsewhere it is the process ID (os.Getpid()).
 	//   - When a fork is detected, the DRBG securely reseeds its cryptographic state, preventing random
 	//     stream duplication and ensuring forward and backward security in both parent and child processes.
 	//
@@ -528,16 +726,56 @@ type drbg struct {
 	//     userspace CSPRNGs in forking environments (Linux, macOS).
 	//   - Aligns the safety guarantees of userspace DRBGs with those provided by kernel-backed CSPRNGs,
 	//     such as Linux getrandom(2), which handle fork-safety internally.
-	pid int
+	forkEpoch uint64
 
-	// rekeying is an atomic flag (0 or 1) that guards rekey attempts.
-	//
-	// It ensures that only one goroutine performs rekeying at a time.
-	// Uses atomic operations for concurrency safety.
-	rekeying uint32
+	// forkChecks counts output requests for interval-based fork detection (ForkDetectionInterval > 0).
+	// It is kept separate from requests so fork checks do not advance the reseed request counter.
+	forkChecks uint64
+
+	// genEpoch is the VM generation epoch observed at this instance's last generation ID check.
+	// It is compared against the Reader-wide watcher to detect snapshot restores and clones.
+	genEpoch uint64
+
+	// rekeying is set by the owner when it starts a background rekey and cleared once the result has
+	// been applied, so at most one rekey is in flight per instance.
+	rekeying bool
+
+	// rekeyResult is the handoff from the background rekey goroutine to the owner: rekeyPending while
+	// seed material is being prepared, then rekeyReady or rekeyFailed. The owner only loads it while
+	// rekeying is set, so requests without a rekey in flight perform no atomic operations.
+	rekeyResult atomic.Uint32
+
+	// rekeyAsyncErr is the last failure of a background rekey, written by the rekey goroutine before it
+	// publishes rekeyFailed.
+	rekeyAsyncErr error
+
+	// rekeyElapsed is the seed derivation time of a background rekey, written by the rekey goroutine
+	// before it publishes rekeyReady.
+	rekeyElapsed time.Duration
+
+	// rekeyErr is the unresolved key rotation failure enforced by RekeyFailurePolicy, or nil. It is set
+	// only under the fail-closed and fallback-reseed policies and cleared once a new key is installed.
+	rekeyErr *RekeyError
 
 	// True once first output block recorded
 	healthTestReady bool
+
+	// claim records whether the instance is idle, in use, or uninstantiated (see instancePool). It is
+	// changed once when the instance is taken from its pool and once when it is returned.
+	claim atomic.Uint32
+
+	// rekeyWG tracks the background rekey goroutine, so uninstantiate can wait for it.
+	rekeyWG sync.WaitGroup
+
+	// stats holds the instance's activity counters, aggregated by Reader.Stats.
+	stats instanceStats
+
+	// instance numbers the instance within its shard, from 1, for audit records.
+	instance uint64
+
+	// seedSource is the name of the entropy source that supplied the installed seed, for audit records.
+	// When the configured source is a SourceReporter, it names the member that served the read.
+	seedSource string
 }
 
 // Add this method:
@@ -548,6 +786,8 @@ func (d *drbg) continuousHealthTest(block []byte) error {
 	var curr [16]byte
 	copy(curr[:], block[:16])
 	if d.healthTestReady && subtle.ConstantTimeCompare(d.lastOutputBlock[:], curr[:]) == 1 {
+		d.stats.healthTestFailures.Add(1)
+		d.healthFailureEvent()
 		return ErrHealthTestFailed
 	}
 	copy(d.lastOutputBlock[:], curr[:])
@@ -559,17 +799,18 @@ func (d *drbg) continuousHealthTest(block []byte) error {
 //
 // This method implements the io.Reader interface for drbg, providing a FIPS 140-2 aligned
 // deterministic random bit generator using the AES-CTR-DRBG construction. Each call to Read
-// returns a unique cryptographically strong pseudo-random stream and is safe for concurrent use.
+// returns a unique cryptographically strong pseudo-random stream. It must not be called concurrently.
 //
 // Semantics and Implementation Details:
-//   - A snapshot of the current cryptographic state (key, block cipher, initial counter value) is loaded atomically.
-//   - The DRBG's internal counter (v) is protected by a mutex to guarantee atomic advancement and persistence
-//     between consecutive reads. This ensures that no two Read calls can produce overlapping output, and that
-//     the generator stream is continuous and non-repeating.
+//   - The instance is owned by the calling goroutine for the duration of the request, so the cryptographic
+//     state (key, block cipher, initial counter value) and the internal counter (v) are accessed without
+//     locks or atomic operations. No two Read calls can produce overlapping output, and the generator
+//     stream is continuous and non-repeating.
 //   - After generating the requested output, the advanced counter is persisted back to the DRBG instance.
-//   - If key rotation is enabled and the generated output exceeds the configured threshold, an asynchronous
-//     rekey operation is triggered. Rekeying swaps the cryptographic state atomically and resets the counter
-//     (under lock) to guarantee forward secrecy and FIPS alignment.
+//   - If key rotation is enabled and the generated output reaches the configured threshold, the key is
+//     rotated according to RekeyMode. In the default asynchronous mode, the new seed is prepared in the
+//     background and installed by the owner at its next request, which replaces the cryptographic state
+//     and resets the counter to guarantee forward secrecy and FIPS alignment.
 //
 // Parameters:
 //   - b: Output buffer to be filled with cryptographically secure random bytes.
@@ -589,11 +830,15 @@ func (d *drbg) Read(b []byte) (int, error) {
 		return 0, ErrRequestTooLarge
 	}
 
+	d.applyRekey()
 	d.reseedIfForked()
+	if err := d.reseedIfGenerationChanged(); err != nil {
+		return 0, err
+	}
 
 	// Prediction Resistance
 	if d.config.PredictionResistance {
-		if err := d.reseed(nil); err != nil {
+		if err := d.reseed(ReseedCausePredictionResistance, nil); err != nil {
 			return 0, fmt.Errorf("prediction resistance reseed failed: %w", err)
 		}
 	} else {
@@ -601,66 +846,80 @@ func (d *drbg) Read(b []byte) (int, error) {
 		if d.config.ReseedInterval > 0 {
 			now := time.Now()
 			if now.Sub(d.lastReseedTime) >= d.config.ReseedInterval {
-				if err := d.reseed(nil); err != nil {
+				if err := d.reseed(ReseedCauseInterval, nil); err != nil {
 					return 0, fmt.Errorf("interval reseed failed: %w", err)
 				}
 			}
 		}
 
 		// NIST-required: Reseed if the configured request count is exceeded.
-		if d.config.ReseedRequests > 0 && atomic.LoadUint64(&d.requests) >= d.config.ReseedRequests {
-			if err := d.reseed(nil); err != nil {
+		if d.config.ReseedRequests > 0 && d.requests >= d.config.ReseedRequests {
+			if err := d.reseed(ReseedCauseRequestCount, nil); err != nil {
 				return 0, fmt.Errorf("request-count reseed failed: %w", err)
 			}
 		}
 	}
 
-	// Atomically load the current DRBG cryptographic state.
-	st := d.state.Load()
+	// Rotate the key first if RekeyModeInline requires it, and enforce RekeyFailurePolicy if the
+	// current key is exhausted and could not be rotated.
+	if d.config.EnableKeyRotation {
+		if err := d.rekeyIfDue(n); err != nil {
+			return 0, err
+		}
+	}
 
-	// Lock the counter mutex to guarantee exclusive access to the evolving counter.
-	d.vMu.Lock()
+	// Load the current DRBG cryptographic state. Only the owner replaces it, so it is stable
+	// for the rest of the request.
+	st := d.state
 
-	// Copy the current counter value to a local variable. This snapshot forms the basis
-	// of the unique keystream for this read operation.
-	copy(d.encV[:], d.v[:])
+	// generated counts keystream bytes produced for this request, for key rotation accounting.
+	generated := n
+	if d.cacheEnabled(n) {
+		// Serve small reads from the pre-generated keystream cache (see KeystreamCacheSize).
+		var err error
+		if generated, err = d.readCached(b, st); err != nil {
+			return 0, err
+		}
+	} else {
+		// Copy the current counter value to a local variable. This snapshot forms the basis
+		// of the unique keystream for this read operation.
+		copy(d.encV[:], d.v[:])
+
+		// Fill the output buffer using the current cryptographic state and the local counter,
+		// incrementing the counter as output is produced. All counter increments are reflected
+		// in the local variable.
+		d.fillBlocks(b, st, &d.encV)
+
+		if d.config.ContinuousHealthTest {
+			if err := d.continuousHealthTest(b); err != nil {
+				return 0, err
+			}
+		}
 
-	// Fill the output buffer using the current cryptographic state and the local counter,
-	// incrementing the counter as output is produced. All counter increments are reflected
-	// in the local variable.
-	d.fillBlocks(b, st, &d.encV)
+		// Persist the advanced counter back to the DRBG instance, ensuring subsequent reads
+		// continue the keystream seamlessly without overlap or repetition.
+		copy(d.v[:], d.encV[:])
+	}
 
-	if d.config.ContinuousHealthTest {
-		if err := d.continuousHealthTest(b); err != nil {
-			d.vMu.Unlock()
+	// SP 800-90C §4.4: RBG3(XOR) combines DRBG output with fresh entropy source output.
+	if d.config.Construction == ConstructionRBG3XOR {
+		if err := d.xorEntropy(b); err != nil {
 			return 0, err
 		}
 	}
 
-	// Persist the advanced counter back to the DRBG instance, ensuring subsequent reads
-	// continue the keystream seamlessly without overlap or repetition.
-	copy(d.v[:], d.encV[:])
-
-	// Unlock the mutex, allowing other callers to proceed.
-	d.vMu.Unlock()
-
 	// NIST-required: Increment the requests counter for this DRBG instance.
 	if !d.config.PredictionResistance {
-		atomic.AddUint64(&d.requests, 1)
+		d.requests++
 	}
 
-	// Key rotation logic: atomically update the usage counter and, if the output threshold is
-	// exceeded, trigger asynchronous rekeying in a background goroutine. Only one goroutine
-	// may perform rekeying at a time.
+	// Key rotation logic: update the usage counter and, once the output threshold is reached,
+	// rotate the key according to the configured RekeyMode.
 	if d.config.EnableKeyRotation {
-		atomic.AddUint64(&d.usage, uint64(len(b)))
-		if atomic.LoadUint64(&d.usage) >= d.config.MaxBytesPerKey {
-			if atomic.CompareAndSwapUint32(&d.rekeying, 0, 1) {
-				go d.asyncRekey()
-			}
-		}
+		d.trackUsage(generated)
 	}
 
+	d.stats.generated(n)
 	return n, nil
 }
 
@@ -679,10 +938,10 @@ func (d *drbg) Read(b []byte) (int, error) {
 //     ignoring additionalInput (NIST-compliant).
 //   - If PredictionResistance is not enabled and additionalInput is non-nil, reseeds using both entropy
 //     and the caller's input, per NIST specification.
-//   - Loads the current cryptographic state (AES key, block cipher, initial counter) atomically.
-//   - The DRBG's internal counter (v) is protected by a mutex to guarantee non-overlapping output across reads.
+//   - Uses the current cryptographic state (AES key, block cipher, initial counter) owned by the caller.
+//   - The DRBG's internal counter (v) is advanced without locks; the instance is never shared during a request.
 //   - Output is generated using fillBlocks and the advanced counter value is persisted for continuity.
-//   - If key rotation is enabled and the usage threshold is exceeded, an asynchronous rekey is triggered.
+//   - If key rotation is enabled and the usage threshold is reached, the key is rotated according to RekeyMode.
 //
 // Parameters:
 //   - b []byte: Output buffer to be filled with cryptographically secure random bytes.
@@ -710,12 +969,16 @@ func (d *drbg) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, e
 		return 0, ErrRequestTooLarge
 	}
 
+	d.applyRekey()
 	d.reseedIfForked()
+	if err := d.reseedIfGenerationChanged(); err != nil {
+		return 0, err
+	}
 
 	// If PredictionResistance is enabled, always reseed from fresh entropy before output,
 	// ignoring any additional input per NIST SP 800-90A requirements.
 	if d.config.PredictionResistance {
-		if err := d.reseed(nil); err != nil {
+		if err := d.reseed(ReseedCausePredictionResistance, nil); err != nil {
 			return 0, fmt.Errorf("prediction resistance reseed failed: %w", err)
 		}
 	} else {
@@ -723,15 +986,15 @@ func (d *drbg) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, e
 		if d.config.ReseedInterval > 0 {
 			now := time.Now()
 			if now.Sub(d.lastReseedTime) >= d.config.ReseedInterval {
-				if err := d.reseed(nil); err != nil {
+				if err := d.reseed(ReseedCauseInterval, nil); err != nil {
 					return 0, fmt.Errorf("interval reseed failed: %w", err)
 				}
 			}
 		}
 
 		// NIST-required: Reseed if the configured request count is exceeded.
-		if d.config.ReseedRequests > 0 && atomic.LoadUint64(&d.requests) >= d.config.ReseedRequests {
-			if err := d.reseed(nil); err != nil {
+		if d.config.ReseedRequests > 0 && d.requests >= d.config.ReseedRequests {
+			if err := d.reseed(ReseedCauseRequestCount, nil); err != nil {
 				return 0, fmt.Errorf("request-count reseed failed: %w", err)
 			}
 		}
@@ -739,55 +1002,75 @@ func (d *drbg) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, e
 		// If additionalInput is provided and prediction resistance is not enabled,
 		// reseed using both entropy and additional input for this output request.
 		if additionalInput != nil {
-			if err := d.reseed(additionalInput); err != nil {
+			if d.config.Construction == ConstructionRBG1 {
+				// RBG1 may not reseed: apply additional input via the SP 800-90A update function.
+				if err := d.update(additionalInput); err != nil {
+					return 0, fmt.Errorf("update with additional input failed: %w", err)
+				}
+			} else if err := d.reseed(ReseedCauseAdditionalInput, additionalInput); err != nil {
 				return 0, fmt.Errorf("reseed with additional input failed: %w", err)
 			}
 		}
 	}
 
-	// Load the current cryptographic state (AES key, block cipher, initial counter) atomically.
-	st := d.state.Load()
+	// Rotate the key first if RekeyModeInline requires it, and enforce RekeyFailurePolicy if the
+	// current key is exhausted and could not be rotated.
+	if d.config.EnableKeyRotation {
+		if err := d.rekeyIfDue(n); err != nil {
+			return 0, err
+		}
+	}
 
-	// Lock the counter mutex to guarantee exclusive access to the evolving counter.
-	d.vMu.Lock()
+	// Load the current cryptographic state (AES key, block cipher, initial counter). Only the
+	// owner replaces it, so it is stable for the rest of the request.
+	st := d.state
 
-	// Copy the current counter value to a local variable for use in output generation.
-	copy(d.encV[:], d.v[:])
+	// generated counts keystream bytes produced for this request, for key rotation accounting.
+	generated := n
+	if d.cacheEnabled(n) {
+		// Serve small reads from the pre-generated keystream cache (see KeystreamCacheSize).
+		var err error
+		if generated, err = d.readCached(b, st); err != nil {
+			return 0, err
+		}
+	} else {
+		// Copy the current counter value to a local variable for use in output generation.
+		copy(d.encV[:], d.v[:])
 
-	// Fill the output buffer using the current cryptographic state and the local counter,
-	// incrementing the counter as output is produced.
-	d.fillBlocks(b, st, &d.encV)
+		// Fill the output buffer using the current cryptographic state and the local counter,
+		// incrementing the counter as output is produced.
+		d.fillBlocks(b, st, &d.encV)
 
-	if d.config.ContinuousHealthTest {
-		if err := d.continuousHealthTest(b); err != nil {
-			d.vMu.Unlock()
-			return 0, err
+		if d.config.ContinuousHealthTest {
+			if err := d.continuousHealthTest(b); err != nil {
+				return 0, err
+			}
 		}
-	}
 
-	// Persist the advanced counter back to the DRBG instance, ensuring
-	// future reads continue the keystream seamlessly.
-	copy(d.v[:], d.encV[:])
+		// Persist the advanced counter back to the DRBG instance, ensuring
+		// future reads continue the keystream seamlessly.
+		copy(d.v[:], d.encV[:])
+	}
 
-	// Unlock the mutex, allowing other callers to proceed.
-	d.vMu.Unlock()
+	// SP 800-90C §4.4: RBG3(XOR) combines DRBG output with fresh entropy source output.
+	if d.config.Construction == ConstructionRBG3XOR {
+		if err := d.xorEntropy(b); err != nil {
+			return 0, err
+		}
+	}
 
 	// NIST-required: Increment the requests counter for this DRBG instance.
 	if !d.config.PredictionResistance {
-		atomic.AddUint64(&d.requests, 1)
+		d.requests++
 	}
 
-	// Key rotation logic: update the usage counter and, if the output threshold is
-	// exceeded, trigger asynchronous rekeying in a background goroutine.
+	// Key rotation logic: update the usage counter and, once the output threshold is reached,
+	// rotate the key according to the configured RekeyMode.
 	if d.config.EnableKeyRotation {
-		atomic.AddUint64(&d.usage, uint64(n))
-		if atomic.LoadUint64(&d.usage) >= d.config.MaxBytesPerKey {
-			if atomic.CompareAndSwapUint32(&d.rekeying, 0, 1) {
-				go d.asyncRekey()
-			}
-		}
+		d.trackUsage(generated)
 	}
 
+	d.stats.generated(n)
 	return n, nil
 }
 
@@ -815,18 +1098,53 @@ func (d *drbg) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, e
 //	    log.Fatalf("reseed failed: %v", err)
 //	}
 func (d *drbg) Reseed(additionalInput []byte) error {
+	// SP 800-90C §4.2: An RBG1 is seeded once and may not be reseeded.
+	if d.config.Construction == ConstructionRBG1 {
+		return ErrReseedNotPermitted
+	}
+
 	// Reseed the DRBG instance using system entropy and any caller-provided additional input.
 	// The reseed function will cryptographically mix system entropy, personalization, and additionalInput,
 	// replacing the internal key, counter, and AES state atomically. If reseed fails, the previous state is retained.
-	return d.reseed(additionalInput)
+	return d.reseed(ReseedCauseManual, additionalInput)
+}
+
+// uninstantiate implements the SP 800-90A §9.4 Uninstantiate function: it waits for any background rekey
+// to finish, then zeroizes the key, counter (V), and every working buffer of the instance.
+//
+// The AES key schedules held by the standard library's cipher.Block values cannot be cleared; they are
+// released so that they become unreachable. The instance must not be used afterwards.
+func (d *drbg) uninstantiate() {
+	d.rekeyWG.Wait()
+	d.rekeying = false
+
+	for i := range d.slots {
+		subtle.XORBytes(d.slots[i].key[:], d.slots[i].key[:], d.slots[i].key[:])
+		subtle.XORBytes(d.slots[i].v[:], d.slots[i].v[:], d.slots[i].v[:])
+		d.slots[i].block = nil
+	}
+	d.state = nil
+	d.ctr = nil
+	d.ctrState = nil
+
+	clear(d.v[:])
+	clear(d.encV[:])
+	clear(d.tmp[:])
+	clear(d.ctrV[:])
+	clear(d.lastOutputBlock[:])
+	clear(d.xorBuf)
+	d.reseedBuf.wipe()
+	d.rekeyBuf.wipe()
+	d.wipeCache()
+
+	d.auditEvent(AuditZeroize, "")
 }
 
 // fillBlocks fills the byte slice `b` with cryptographically secure, deterministic random data
 // generated from the provided DRBG state and a caller-provided working counter.
 //
-// This method implements the core NIST SP 800-90A AES-CTR-DRBG output logic. It is concurrency safe
-// as it operates only on immutable state and caller-provided (session-local) counter. No DRBG struct
-// fields are mutated during block generation.
+// This method implements the core NIST SP 800-90A AES-CTR-DRBG output logic. The cached keystream
+// (ctr, ctrState, ctrV) is per-instance state, owned by the caller like the rest of the instance.
 //
 // Parameters:
 //   - b   []byte:      Output buffer to be filled with random bytes. Must be at least 1 byte in length.
@@ -834,16 +1152,19 @@ func (d *drbg) Reseed(additionalInput []byte) error {
 //   - v   *[16]byte:   Session-local working counter for this output operation. Advanced in place.
 //
 // Behavior:
-//   - Processes output in 16-byte (AES block size) chunks for maximal efficiency.
-//   - For each block, increments the session-local counter, encrypts it, and writes the result to output.
+//   - Output block i is AES(Key, V+i), for i = 1..ceil(len(b)/16), exactly as if V were incremented
+//     and encrypted once per block (SP 800-90A §10.2.1.5.1). V is then advanced by ceil(len(b)/16).
+//   - The keystream is produced by crypto/cipher's CTR mode, which encrypts eight blocks at a time on
+//     AES-NI / ARMv8 hardware. Both increment V as a 128-bit big-endian integer, wrapping modulo 2^128.
+//   - The stream is cached between requests and recreated only when the state or counter changes. The
+//     remainder of a final partial block is discarded, so every request begins on a fresh block.
 //   - Supports two strategies:
-//   - UseZeroBuffer: Encrypted blocks are staged in a reusable buffer before being copied out (reducing allocations).
-//   - Fast path: Output is written directly into the caller's buffer except for a possible tail partial block,
-//     which uses the persistent drbg.tmp [16]byte buffer.
+//   - UseZeroBuffer: The keystream is XORed against a reusable zero-filled buffer into the caller's buffer.
+//   - Fast path: The caller's buffer is cleared and the keystream is XORed in place.
 //
 // Security:
 //   - Ensures every 16-byte block is generated with a unique counter value per NIST recommendations.
-//   - Never mutates DRBG fields or global internal state directly.
+//   - Keystream bytes are never reused: a discarded tail is never emitted by a later request.
 //
 // Panics:
 //   - Never panics under normal operation. Will panic only if AES block size invariants are violated
@@ -855,38 +1176,52 @@ func (d *drbg) fillBlocks(b []byte, st *state, v *[16]byte) {
 		return
 	}
 
-	// Buffered output mode: stage keystream in reusable buffer to minimize allocations.
+	// Reuse the cached stream only if it is positioned exactly at (st, v). Otherwise, small requests
+	// are generated block by block, which avoids allocating a stream for the first read after every
+	// reseed (e.g., with prediction resistance); larger requests start a new stream at the block
+	// following v.
+	if d.ctr == nil || d.ctrState != st || d.ctrV != *v {
+		if n < ctrStreamMinBytes {
+			d.ctr, d.ctrState = nil, nil
+			d.fillBlocksSerial(b, st, v)
+			return
+		}
+		iv := *v
+		incV(&iv)
+		d.ctr = cipher.NewCTR(st.block, iv[:])
+		d.ctrState = st
+	}
+
 	if d.config.UseZeroBuffer {
-		// Ensure the zero buffer is large enough; allocate if needed.
+		// Buffered output mode: XOR the keystream against a reusable zero-filled buffer.
 		if cap(d.zero) < n {
 			d.zero = make([]byte, n)
 		}
 		d.zero = d.zero[:n] // Resize without reallocating if possible.
+		d.ctr.XORKeyStream(b, d.zero)
+	} else {
+		// Fast path: generate the keystream directly into the caller's buffer.
+		clear(b)
+		d.ctr.XORKeyStream(b, b)
+	}
 
-		offset := 0
-		remaining := n
-		for remaining > 0 {
-			// Determine block size for this iteration (full or final partial block).
-			blockSize := 16
-			if remaining < 16 {
-				blockSize = remaining
-			}
-
-			// Advance the session-local counter as required by CTR mode (one block per keystream segment).
-			incV(v)
-
-			// Encrypt the incremented counter; write keystream into zero buffer.
-			st.block.Encrypt(d.zero[offset:offset+blockSize], v[:])
-
-			// Copy encrypted keystream to caller's buffer.
-			copy(b[offset:offset+blockSize], d.zero[offset:offset+blockSize])
-			offset += blockSize
-			remaining -= blockSize
-		}
-		return
+	// Discard the rest of a final partial block, keeping the stream block-aligned with V.
+	blocks := uint64(n+15) / 16
+	if tail := n % 16; tail != 0 {
+		clear(d.tmp[:])
+		d.ctr.XORKeyStream(d.tmp[:16-tail], d.tmp[:16-tail])
+		clear(d.tmp[:])
 	}
 
-	// Fast path: direct write to output buffer, except for a final partial block.
+	// Advance the counter past every block consumed by this request.
+	addV(v, blocks)
+	d.ctrV = *v
+}
+
+// fillBlocksSerial fills b by incrementing v and encrypting it once per 16-byte block, writing a final
+// partial block through the persistent tmp buffer. It produces the same output as the CTR stream path.
+func (d *drbg) fillBlocksSerial(b []byte, st *state, v *[16]byte) {
+	n := len(b)
 	offset := 0
 	for ; offset+16 <= n; offset += 16 {
 		incV(v)
@@ -898,13 +1233,14 @@ func (d *drbg) fillBlocks(b []byte, st *state, v *[16]byte) {
 		incV(v)
 		st.block.Encrypt(d.tmp[:], v[:])
 		copy(b[offset:], d.tmp[:tail])
+		clear(d.tmp[:])
 	}
 }
 
 // reseed refreshes the DRBG instance with new system entropy, personalization, and optional additional input.
 //
 // This function generates a new internal state (AES key, counter, and cipher block) using the provided DRBG
-// configuration and any additionalInput supplied by the caller. It atomically installs the new cryptographic state,
+// configuration and any additionalInput supplied by the caller. It installs the new cryptographic state,
 // ensuring no overlap with previous keystream output. After reseed, both the byte usage counter and request count
 // are reset, and the reseed timestamp is updated to support interval/request-count-based reseed policies.
 //
@@ -916,84 +1252,147 @@ func (d *drbg) fillBlocks(b []byte, st *state, v *[16]byte) {
 //   - error: Non-nil if entropy acquisition or state initialization fails; nil on success.
 //
 // Concurrency Notes:
-//   - This method synchronizes counter updates using a mutex and uses atomic operations for usage/request counters.
-//   - If called concurrently, it is possible for closely timed reseeds to slightly race in updating the metadata fields;
-//     this does not impact cryptographic safety.
-func (d *drbg) reseed(additionalInput []byte) error {
-	// Generate a new DRBG state (key, counter, AES block) using system entropy,
-	// personalization string, and optional additional input.
-	newState, err := newDRBGState(d.config, additionalInput)
+//   - This method must be called by the goroutine that owns the instance; it is never called by the
+//     background rekey goroutine, so the reseed metadata (lastReseedTime, requests) is not shared.
+func (d *drbg) reseed(cause ReseedCause, additionalInput []byte) error {
+	start := time.Now()
+
+	// Derive new seed material (key and counter) from system entropy, the personalization
+	// string, and optional additional input, using the instance's reusable seed buffer.
+	seed, err := d.deriveSeed(&d.reseedBuf, additionalInput)
+	defer d.reseedBuf.wipe()
+	if err == nil {
+		// Install the new cryptographic state and reset the working counter (v)
+		// and usage counter, ensuring unique, non-overlapping output.
+		err = d.installSeed(seed)
+	}
 	if err != nil {
+		d.reseedEvent(cause, time.Since(start), err)
 		return err
 	}
-
-	// Atomically install the new cryptographic state for this DRBG instance.
-	d.state.Store(newState)
-
-	// Acquire the counter mutex and update the working counter (v)
-	// to match the new state, ensuring unique, non-overlapping output.
-	d.vMu.Lock()
-	copy(d.v[:], newState.v[:])
-	d.vMu.Unlock()
-
-	// Reset the usage counter, guaranteeing fresh key usage tracking.
-	atomic.StoreUint64(&d.usage, 0)
+	d.seedSource = d.reseedBuf.source
 
 	// Update reseed tracking metadata.
 	d.lastReseedTime = time.Now()
-	atomic.StoreUint64(&d.requests, 0)
+	d.requests = 0
+	d.stats.reseeds[cause].Add(1)
+	d.reseedEvent(cause, d.lastReseedTime.Sub(start), nil)
 
 	return nil
 }
 
-// newDRBGState generates a fresh, fully initialized DRBG state (AES key and counter) per NIST SP 800-90A.
+// seedScratch is a reusable working buffer for deriving seed material without allocating.
+//
+// Each instance holds one for its owner's reseed path and one for the asynchronous rekey goroutine,
+// so the two never share a buffer. Contents are wiped after every use.
+type seedScratch struct {
+	// seed holds entropy input for the key (up to 32 bytes) followed by the 128-bit counter (V).
+	seed [32 + 16]byte
+
+	// extra holds supplemental input read from Config.AdditionalInputSource.
+	extra [supplementalInputLen]byte
+
+	// source is the name of the entropy source that supplied seed.
+	source string
+}
+
+// wipe zeroizes the scratch buffer.
+func (s *seedScratch) wipe() {
+	clear(s.seed[:])
+	clear(s.extra[:])
+	s.source = ""
+}
+
+// deriveSeed fills buf with fresh seed material per NIST SP 800-90A and returns it as a KeySize + 16 byte slice.
 //
 // This function combines system entropy, the configured personalization string, and optional additional input
-// to derive a unique and unpredictable state. This process aligns with NIST recommendations for DRBG
+// to derive a unique and unpredictable seed. This process aligns with NIST recommendations for DRBG
 // instantiation and reseed, ensuring domain separation and strong security.
 //
-// The returned state encapsulates an AES block cipher initialized with the derived key, a copy of the key
-// material, and the initial 128-bit counter value (V). If entropy acquisition or cipher creation fails,
-// an error is returned and no state is produced.
-//
 // Parameters:
-//   - cfg *Config: The DRBG configuration, including key size, personalization, and related options.
+//   - buf *seedScratch: Reusable working buffer; the caller must wipe it once the seed is installed.
 //   - additionalInput []byte: Optional per-call entropy or context to further randomize the state; may be nil.
 //
 // Returns:
-//   - *state: Newly derived DRBG state with a fresh key, cipher, and counter.
-//   - error: Non-nil if entropy acquisition or cipher construction fails; nil on success.
-func newDRBGState(cfg *Config, additionalInput []byte) (*state, error) {
-	seedLen := cfg.KeySize + 16
-	seed := make([]byte, seedLen)
-
-	// Acquire fresh entropy from the operating system. This forms the basis of the DRBG seed material.
-	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+//   - []byte: The seed (key followed by counter V), backed by buf.
+//   - error: Non-nil if entropy acquisition fails; nil on success.
+func (d *drbg) deriveSeed(buf *seedScratch, additionalInput []byte) ([]byte, error) {
+	seed := buf.seed[:d.config.KeySize+16]
+
+	// Acquire fresh entropy from the configured source. This forms the basis of the DRBG seed material.
+	source, err := readEntropy(d.config, seed)
+	if err != nil {
 		return nil, err
 	}
+	buf.source = source
+	d.stats.entropyBytes.Add(uint64(len(seed)))
 
 	// Incorporate the personalization string, if provided, by XOR-ing it into the seed for domain separation.
 	// Mix in any caller-supplied additional input by XOR-ing it into the seed, further randomizing the state.
-	mixSeed(seed, cfg.Personalization, additionalInput)
+	mixSeed(seed, d.config.Personalization, additionalInput)
 
-	// Split the seed buffer into an AES key (of the configured size) and a 128-bit counter (V).
-	var key [32]byte
-	copy(key[:], seed[:cfg.KeySize])
-	var v [16]byte
-	copy(v[:], seed[cfg.KeySize:])
+	// Mix in non-credited supplemental input (e.g., a randomness beacon), if configured.
+	mixSeed(seed, nil, readSupplemental(d.config, buf.extra[:]))
+
+	return seed, nil
+}
+
+// installSeed derives a new cryptographic state from seed (KeySize bytes of key followed by a 16-byte
+// counter V), installs it, resets the working counter (V) and the per-key usage counter, and discards any
+// keystream generated under the previous state.
+//
+// The state is written into whichever of the instance's two slots is not current, so reseeding does not
+// allocate a new state; only the AES key schedule (aes.NewCipher) is allocated, as crypto/aes provides no
+// way to re-key an existing cipher. It must be called by the goroutine that owns the instance.
+//
+// If EnableZeroization is set, the previous key material and working counter are zeroized first
+// (FIPS 140-2 §4.7.6).
+//
+// Returns an error if the AES cipher cannot be constructed; the current state is then left unchanged.
+func (d *drbg) installSeed(seed []byte) error {
+	// Select the slot that is not currently installed.
+	old := d.state
+	next := &d.slots[0]
+	if old == next {
+		next = &d.slots[1]
+	}
 
-	// Initialize the AES block cipher using the derived key. Return an error if cipher creation fails.
-	block, err := aes.NewCipher(key[:cfg.KeySize])
+	// Split the seed into an AES key (of the configured size) and a 128-bit counter (V), and
+	// initialize the AES block cipher using the derived key.
+	clear(next.key[:])
+	copy(next.key[:], seed[:d.config.KeySize])
+	block, err := aes.NewCipher(next.key[:d.config.KeySize])
 	if err != nil {
-		return nil, err
+		clear(next.key[:])
+		return err
 	}
+	next.block = block
+	copy(next.v[:], seed[d.config.KeySize:])
+
+	// FIPS 140-2 §4.7.6: Zeroize old key material before replacement.
+	if d.config.EnableZeroization && old != nil {
+		// Use subtle.XORBytes to prevent compiler optimization.
+		subtle.XORBytes(old.key[:], old.key[:], old.key[:])
+		subtle.XORBytes(old.v[:], old.v[:], old.v[:])
+	}
+
+	// Install the new cryptographic state. A fresh key resolves any pending rotation failure.
+	d.state = next
+	d.usage = 0
+	d.rekeyErr = nil
+
+	// Zeroize old working counter before overwriting.
+	if d.config.EnableZeroization {
+		subtle.XORBytes(d.v[:], d.v[:], d.v[:])
+	}
+	copy(d.v[:], next.v[:])
+
+	// Drop the keystream and cached output generated under the previous state.
+	d.ctr = nil
+	d.ctrState = nil
+	d.wipeCache()
 
-	// Construct and return the new DRBG state, encapsulating the cipher, key, and counter.
-	return &state{
-		block: block,
-		key:   key,
-		v:     v,
-	}, nil
+	return nil
 }
 
 // newDRBG creates and returns a new, fully initialized deterministic random bit generator (DRBG) instance.
@@ -1005,7 +1404,7 @@ func newDRBGState(cfg *Config, additionalInput []byte) (*state, error) {
 //  3. Derive the AES key and initial counter (V) from the seed.
 //  4. Construct the AES block cipher with the derived key, and fail if the cipher cannot be created.
 //  5. Optionally allocate a reusable zero buffer if requested in configuration.
-//  6. Store the resulting cryptographic state atomically and initialize the working counter (v) from this state.
+//  6. Install the resulting cryptographic state and initialize the working counter (v) from this state.
 //
 // If entropy acquisition or cipher construction fails, an error is returned and the DRBG is not created.
 //
@@ -1016,54 +1415,40 @@ func newDRBGState(cfg *Config, additionalInput []byte) (*state, error) {
 //   - *drbg: newly initialized DRBG instance, ready for use
 //   - error: non-nil if any initialization step fails (entropy, cipher, or config error)
 func newDRBG(cfg *Config) (*drbg, error) {
-	seedLen := cfg.KeySize + 16
-
-	// Allocate a buffer for the full seed: key + 128-bit counter.
-	seed := make([]byte, seedLen)
-
-	// Read entropy from the operating system. Fail if not available.
-	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
-		return nil, err
+	// Optionally preallocate the zero buffer for buffer-reuse mode.
+	var zero []byte
+	if cfg.UseZeroBuffer && cfg.DefaultBufferSize > 0 {
+		zero = make([]byte, cfg.DefaultBufferSize)
 	}
 
-	// XOR in personalization string (if any) for domain separation.
-	mixSeed(seed, cfg.Personalization, nil)
-
-	// Derive the AES key and the initial counter (V) from the seed.
-	var key [32]byte
-	copy(key[:], seed[:cfg.KeySize])
-	var v [16]byte
-	copy(v[:], seed[cfg.KeySize:])
+	d := &drbg{
+		config:    cfg,
+		zero:      zero,
+		forkEpoch: processEpoch(),
+	}
 
-	// Construct the AES block cipher using the derived key.
-	block, err := aes.NewCipher(key[:cfg.KeySize])
+	// Read entropy from the configured source, XOR in the personalization string (if any) for
+	// domain separation, and derive the AES key, cipher, and initial counter (V). Installing the
+	// state also initializes the working counter, guaranteeing unique output on first use.
+	seed, err := d.deriveSeed(&d.reseedBuf, nil)
+	defer d.reseedBuf.wipe()
 	if err != nil {
 		return nil, err
 	}
-
-	// Optionally preallocate the zero buffer for buffer-reuse mode.
-	var zero []byte
-	if cfg.UseZeroBuffer && cfg.DefaultBufferSize > 0 {
-		zero = make([]byte, cfg.DefaultBufferSize)
+	if err := d.installSeed(seed); err != nil {
+		return nil, err
 	}
+	d.seedSource = d.reseedBuf.source
 
-	// Store the immutable cryptographic state atomically.
-	st := &state{
-		block: block,
-		key:   key,
-		v:     v,
+	// Allocate the (initially empty) keystream cache for small reads, if enabled.
+	if cfg.KeystreamCacheSize > 0 {
+		d.cache = make([]byte, 0, cfg.KeystreamCacheSize)
 	}
-	d := &drbg{
-		config:   cfg,
-		zero:     zero,
-		usage:    0,
-		rekeying: 0,
-		pid:      os.Getpid(),
-	}
-	d.state.Store(st)
 
-	// Initialize the working counter (v) from the state, guaranteeing unique output on first use.
-	copy(d.v[:], v[:])
+	// Record the current VM generation epoch; the instance was just seeded from fresh entropy.
+	if cfg.generation != nil {
+		d.genEpoch = cfg.generation.epoch.Load()
+	}
 
 	return d, nil
 }
@@ -1101,91 +1486,6 @@ func mixSeed(seed []byte, personalization, additionalInput []byte) {
 	}
 }
 
-// asyncRekey performs an asynchronous, non-blocking reseed and key rotation for the DRBG instance.
-//
-// This function is launched in a background goroutine when the generated output exceeds the configured threshold
-// (MaxBytesPerKey). It attempts to generate new entropy, derive a new key and counter, and atomically install a
-// new DRBG state. The working counter (v) is reset to the new initial value under lock. If all attempts to reseed
-// fail, the existing cryptographic state is left unchanged, and the generator continues operating.
-//
-// Steps:
-//  1. Attempt up to MaxRekeyAttempts reseed/rotate cycles, with exponential backoff (bounded by MaxRekeyBackoff).
-//  2. For each attempt:
-//     - Acquire a fresh random seed and optionally apply personalization.
-//     - Derive a new key and counter (V), and construct a new AES cipher.
-//     - On success, atomically store the new state, reset the usage counter, and set the working counter (v).
-//  3. Always clear the rekeying flag before returning (even on panic or error), so future rekeys can proceed.
-//
-// Parameters: None (method receiver only).
-func (d *drbg) asyncRekey() {
-	// Always clear the rekeying flag on exit.
-	defer atomic.StoreUint32(&d.rekeying, 0)
-
-	base := d.config.RekeyBackoff
-	maxBackoff := d.config.MaxRekeyBackoff
-	if maxBackoff == 0 {
-		maxBackoff = defaultMaxBackoff
-	}
-
-	// Attempt to reseed and rekey up to MaxRekeyAttempts times.
-	for i := 0; i < d.config.MaxRekeyAttempts; i++ {
-		// Obtain new entropy for key and counter (V).
-		seedLen := d.config.KeySize + 16 // Key size plus 128-bit counter
-		seed := make([]byte, seedLen)
-		if _, err := io.ReadFull(rand.Reader, seed); err == nil {
-			// Apply personalization string, if set, by XORing into the seed.
-			mixSeed(seed, d.config.Personalization, nil)
-
-			// Construct the new AES key and counter (V) from the seed buffer.
-			var key [32]byte
-			copy(key[:], seed[:d.config.KeySize])
-			var v [16]byte
-			copy(v[:], seed[d.config.KeySize:])
-			var block cipher.Block
-			block, err = aes.NewCipher(key[:d.config.KeySize])
-			if err == nil {
-				// FIPS 140-2 §4.7.6: Zeroize old key material before replacement.
-				if d.config.EnableZeroization {
-					if oldState := d.state.Load(); oldState != nil {
-						// Use subtle.XORBytes to prevent compiler optimization.
-						subtle.XORBytes(oldState.key[:], oldState.key[:], oldState.key[:])
-						subtle.XORBytes(oldState.v[:], oldState.v[:], oldState.v[:])
-					}
-				}
-
-				// Store new cryptographic state atomically.
-				newState := &state{
-					block: block,
-					key:   key,
-					v:     v,
-				}
-				d.state.Store(newState)
-				atomic.StoreUint64(&d.usage, 0)
-
-				// Reset the working counter (v) under mutex lock to ensure no overlap.
-				d.vMu.Lock()
-				// Zeroize old working counter before overwriting.
-				if d.config.EnableZeroization {
-					subtle.XORBytes(d.v[:], d.v[:], d.v[:])
-				}
-				copy(d.v[:], v[:])
-				d.vMu.Unlock()
-				return // Rekey complete.
-			}
-
-			// (If cipher construction fails, fall through and retry after backoff.)
-		}
-
-		// Wait with exponential backoff before retrying.
-		time.Sleep(base)
-		base *= 2
-		if base > maxBackoff {
-			base = maxBackoff
-		}
-	}
-	// If all retries fail, generator continues with prior state.
-}
-
 // incV increments the DRBG counter (V) in big-endian order, rolling over as needed.
 //
 // The counter (V) is treated as a 128-bit unsigned integer in big-endian representation.
@@ -1206,3 +1506,15 @@ func incV(v *[16]byte) {
 		}
 	}
 }
+
+// addV adds n to the 128-bit big-endian counter v, wrapping modulo 2^128.
+//
+// It is equivalent to calling incV n times.
+func addV(v *[16]byte, n uint64) {
+	lo := binary.BigEndian.Uint64(v[8:])
+	hi := binary.BigEndian.Uint64(v[:8])
+	lo, carry := bits.Add64(lo, n, 0)
+	hi += carry
+	binary.BigEndian.PutUint64(v[8:], lo)
+	binary.BigEndian.PutUint64(v[:8], hi)
+}
@@ -7,13 +7,14 @@ package ctrdrbg
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 )
 
 // For benchmarking sync.Pool get/put only (DRBG instancing contention, not output).
 func (r *reader) syncPoolGetPut() {
-	dr := r.pools[0].Get().(*drbg)
-	r.pools[0].Put(dr)
+	dr, _ := r.pools[0].get()
+	r.pools[0].put(dr)
 }
 
 // BenchmarkDRBG_SyncPool_Baseline_Concurrent measures the performance and contention
@@ -258,3 +259,150 @@ func BenchmarkDRBG_Read_PredictionResistance(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkDRBG_FillBlocks compares the per-block generate loop against the pipelined CTR keystream
+// used by fillBlocks for bulk output sizes.
+func BenchmarkDRBG_FillBlocks(b *testing.B) {
+	cfg := DefaultConfig()
+	d, err := newDRBG(&cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	st := d.state
+
+	for _, size := range []int{4096, 16384, 65536} {
+		buf := make([]byte, size)
+		b.Run(fmt.Sprintf("PerBlock_%dBytes", size), func(b *testing.B) {
+			var v [16]byte
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fillBlocksPerBlock(buf, st, &v)
+			}
+		})
+		b.Run(fmt.Sprintf("Pipelined_%dBytes", size), func(b *testing.B) {
+			var v [16]byte
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				d.fillBlocks(buf, st, &v)
+			}
+		})
+	}
+}
+
+// BenchmarkDRBG_ShardStrategy_Concurrent compares shard selection strategies for 32-byte reads
+// across goroutine counts. Each sub-benchmark runs exactly gc goroutines, independent of GOMAXPROCS.
+func BenchmarkDRBG_ShardStrategy_Concurrent(b *testing.B) {
+	strategies := []ShardStrategy{ShardStrategyAffinity, ShardStrategyRandom, ShardStrategyRoundRobin}
+	goroutineCounts := []int{2, 4, 8, 16, 32, 64, 128, 256}
+	for _, strategy := range strategies {
+		rdr, err := NewReader(WithShardStrategy(strategy))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, gc := range goroutineCounts {
+			b.Run(fmt.Sprintf("%s_G%d", strategy, gc), func(b *testing.B) {
+				var wg sync.WaitGroup
+				b.ReportAllocs()
+				b.ResetTimer()
+				for g := 0; g < gc; g++ {
+					// Split b.N across exactly gc goroutines, the first b.N%gc taking one extra read.
+					n := b.N / gc
+					if g < b.N%gc {
+						n++
+					}
+					wg.Add(1)
+					go func(n int) {
+						defer wg.Done()
+						buf := make([]byte, 32)
+						for i := 0; i < n; i++ {
+							_, _ = rdr.Read(buf)
+						}
+					}(n)
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
+
+// BenchmarkDRBG_Read_KeystreamCache compares small serial reads with and without the keystream cache.
+func BenchmarkDRBG_Read_KeystreamCache(b *testing.B) {
+	for _, cacheSize := range []int{0, 4096} {
+		rdr, err := NewReader(WithKeystreamCache(cacheSize))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, size := range []int{8, 16, 32} {
+			b.Run(fmt.Sprintf("Cache%d_%dBytes", cacheSize, size), func(b *testing.B) {
+				buf := make([]byte, size)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, _ = rdr.Read(buf)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkDRBG_Read_RekeyMode compares request throughput and latency across rekey modes with frequent
+// key rotation. Async keeps rekeys off the request path; Sync and Inline pay for them within the request.
+func BenchmarkDRBG_Read_RekeyMode(b *testing.B) {
+	for _, mode := range []RekeyMode{RekeyModeAsync, RekeyModeSync, RekeyModeInline} {
+		rdr, err := NewReader(WithEnableKeyRotation(true), WithMaxBytesPerKey(64*1024), WithRekeyMode(mode))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(mode.String(), func(b *testing.B) {
+			buf := make([]byte, 4096)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(buf)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := rdr.Read(buf); err != nil {
+					b.Fatalf("Read failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDRBG_Read_Session compares many consecutive small reads through the Reader with the same reads
+// through a single Session.
+func BenchmarkDRBG_Read_Session(b *testing.B) {
+	const reads = 16
+	r, err := NewReader()
+	if err != nil {
+		b.Fatal(err)
+	}
+	buffer := make([]byte, 32)
+
+	b.Run("Reader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < reads; j++ {
+				if _, err := r.Read(buffer); err != nil {
+					b.Fatalf("Read failed: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("Session", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s, err := r.Acquire()
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := 0; j < reads; j++ {
+				if _, err := s.Read(buffer); err != nil {
+					b.Fatalf("Read failed: %v", err)
+				}
+			}
+			s.Release()
+		}
+	})
+}
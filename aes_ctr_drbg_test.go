@@ -9,8 +9,8 @@ package ctrdrbg
 
 import (
 	"bytes"
+	"fmt"
 	"sync"
-	"sync/atomic"
 	"testing"
 	"time"
 
@@ -269,30 +269,64 @@ func Test_CTRDRBG_AsyncRekey(t *testing.T) {
 	is.NoError(err)
 
 	// Get a snapshot of the initial state pointer (or block pointer)
-	initialState := d.state.Load()
+	initialState := d.state
 	initialBlock := initialState.block
 
 	buf := make([]byte, 128) // Exceeds MaxBytesPerKey, triggers rekey
 	_, err = d.Read(buf)
 	is.NoError(err)
+	is.True(d.rekeying, "exceeding MaxBytesPerKey should start a rekey")
+	is.Same(initialState, d.state, "the state is only replaced by the owner")
 
-	// Wait for async rekey to finish
-	wait := time.NewTimer(500 * time.Millisecond)
-	tick := time.NewTicker(10 * time.Millisecond)
-	defer wait.Stop()
-	defer tick.Stop()
-	for {
-		select {
-		case <-tick.C:
-			currState := d.state.Load()
-			// Compare block pointers (address equality) to detect a swap
-			if currState.block != initialBlock && atomic.LoadUint64(&d.usage) == 0 {
-				return // success
-			}
-		case <-wait.C:
+	// Wait for async rekey to finish and apply it as the next request would.
+	awaitRekey(t, d)
+
+	// Compare block pointers (address equality) to detect a swap
+	is.NotEqual(initialBlock, d.state.block, "rekey should install a new cipher")
+	is.Zero(d.usage, "rekey should reset the usage counter")
+	is.False(d.rekeying)
+}
+
+// Test_CTRDRBG_AsyncRekey_OwnerApplied verifies that output continues while a rekey is prepared in the
+// background, and that the owner installs it at a later request. Run with -race to check that the
+// background goroutine never touches the state used by the generate path.
+func Test_CTRDRBG_AsyncRekey_OwnerApplied(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.MaxBytesPerKey = 256
+	cfg.EnableKeyRotation = true
+	cfg.ReseedInterval = time.Hour
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	initial := d.state
+	buf := make([]byte, 64)
+	deadline := time.Now().Add(time.Second)
+	for d.state == initial {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the owner to apply the rekey")
+		}
+		_, err = d.Read(buf)
+		is.NoError(err)
+	}
+	is.Less(d.usage, cfg.MaxBytesPerKey, "usage should restart from the applied rekey")
+}
+
+// awaitRekey waits for the background rekey started by d to finish preparing its seed, then applies it
+// as the owner would at its next request.
+func awaitRekey(t *testing.T, d *drbg) {
+	t.Helper()
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for d.rekeyResult.Load() == rekeyPending {
+		if time.Now().After(deadline) {
 			t.Fatal("Timed out waiting for asyncRekey to complete")
 		}
+		time.Sleep(time.Millisecond)
 	}
+	d.applyRekey()
 }
 
 // Test_CTRDRBG_Personalization_Changes_Stream ensures different personalization strings yield unique output streams.
@@ -337,22 +371,15 @@ func Test_CTRDRBG_Read_Shards(t *testing.T) {
 			t.Parallel()
 			is := assert.New(t)
 
-			// hit[i] will be set true if pool[i] is accessed
-			hit := make([]bool, tc.shardCount)
-
-			// Create sync.Pool array, each tracking access via hit[i]
-			pools := make([]*sync.Pool, tc.shardCount)
+			// Create empty pools, each with its own entropy source; a pool constructs (and seeds)
+			// an instance only when it is accessed.
+			sources := make([]*callCountingSource, tc.shardCount)
+			pools := make([]*instancePool, tc.shardCount)
 			for i := 0; i < tc.shardCount; i++ {
-				id := i
-				pools[i] = &sync.Pool{
-					New: func() any {
-						// Record that this shard was used.
-						hit[id] = true
-						cfg := DefaultConfig()
-						d, _ := newDRBG(&cfg)
-						return d
-					},
-				}
+				sources[i] = &callCountingSource{}
+				cfg := DefaultConfig()
+				cfg.EntropySource = sources[i]
+				pools[i] = newInstancePool(&cfg)
 			}
 
 			r := &reader{
@@ -365,8 +392,8 @@ func Test_CTRDRBG_Read_Shards(t *testing.T) {
 
 			// Ensure exactly one shard was accessed.
 			used := -1
-			for i, v := range hit {
-				if v {
+			for i, src := range sources {
+				if src.calls.Load() > 0 {
 					if used != -1 {
 						t.Fatalf("multiple pools were accessed: %d and %d", used, i)
 					}
@@ -386,7 +413,7 @@ func Test_DRBG_FillBlocks_ZeroAlloc(t *testing.T) {
 	var v [16]byte
 	buf := make([]byte, KeySize256)
 
-	st := d.state.Load()
+	st := d.state
 
 	// Warmup and baseline output
 	d.fillBlocks(buf, st, &v)
@@ -455,6 +482,83 @@ func Test_DRBG_Read_ZeroAlloc(t *testing.T) {
 	}
 }
 
+// Test_DRBG_Reseed_Allocs verifies that reseeding paths reuse per-instance buffers and state slots.
+//
+// The only remaining allocation per reseed is the AES key schedule: crypto/aes provides no way to
+// re-key an existing cipher, so aes.NewCipher allocates once for every new key.
+func Test_DRBG_Reseed_Allocs(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(*Config)
+		read      func(d *drbg, b []byte) error
+		reseeds   float64
+	}{
+		{
+			name:      "PredictionResistance",
+			configure: func(c *Config) { c.PredictionResistance = true },
+			read:      func(d *drbg, b []byte) error { _, err := d.Read(b); return err },
+			reseeds:   1,
+		},
+		{
+			name:      "PredictionResistance_AdditionalInput",
+			configure: func(c *Config) { c.PredictionResistance = true },
+			read:      func(d *drbg, b []byte) error { _, err := d.ReadWithAdditionalInput(b, []byte("context")); return err },
+			reseeds:   1,
+		},
+		{
+			name:      "ReseedInterval",
+			configure: func(c *Config) { c.ReseedInterval = time.Nanosecond },
+			read:      func(d *drbg, b []byte) error { _, err := d.Read(b); return err },
+			reseeds:   1,
+		},
+		{
+			name:      "AdditionalInput",
+			configure: func(c *Config) {},
+			read:      func(d *drbg, b []byte) error { _, err := d.ReadWithAdditionalInput(b, []byte("context")); return err },
+			reseeds:   1,
+		},
+		{
+			name:      "Reseed",
+			configure: func(c *Config) { c.EnableZeroization = true },
+			read:      func(d *drbg, _ []byte) error { return d.Reseed([]byte("context")) },
+			reseeds:   1,
+		},
+		{
+			name:      "Rekey",
+			configure: func(c *Config) {},
+			read: func(d *drbg, _ []byte) error {
+				d.rekeying = true
+				d.rekeyWG.Add(1)
+				d.asyncRekey()
+				d.applyRekey()
+				return nil
+			},
+			reseeds: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := assert.New(t)
+
+			cfg := DefaultConfig()
+			tc.configure(&cfg)
+			d, err := newDRBG(&cfg)
+			is.NoError(err)
+
+			buf := make([]byte, 32)
+			is.NoError(tc.read(d, buf))
+			key := d.state.key
+
+			allocs := testing.AllocsPerRun(1000, func() {
+				is.NoError(tc.read(d, buf))
+			})
+			is.LessOrEqual(allocs, tc.reseeds, "only the AES key schedule should be allocated per reseed")
+			is.NotEqual(key, d.state.key, "the path under test should have reseeded")
+		})
+	}
+}
+
 // Test_DRBG_Reader_Config verifies that a Reader reflects its config values as set via functional options.
 func Test_DRBG_Reader_Config(t *testing.T) {
 	t.Parallel()
@@ -540,6 +644,101 @@ func Test_DRBG_CounterOverflow(t *testing.T) {
 	is.False(allZeros, "Output block should not be all zeros")
 }
 
+// cloneState installs a copy of src's current key and working counter (V) into dst.
+func cloneState(t *testing.T, dst, src *drbg) {
+	t.Helper()
+
+	st := src.state
+	seed := make([]byte, 0, 48)
+	seed = append(seed, st.key[:src.config.KeySize]...)
+	seed = append(seed, src.v[:]...)
+	if err := dst.installSeed(seed); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fillBlocksPerBlock is the reference SP 800-90A generate loop: increment V and encrypt it, one block at a time.
+func fillBlocksPerBlock(b []byte, st *state, v *[16]byte) {
+	var tmp [16]byte
+	for off := 0; off < len(b); off += 16 {
+		incV(v)
+		st.block.Encrypt(tmp[:], v[:])
+		copy(b[off:], tmp[:])
+	}
+}
+
+// Test_DRBG_FillBlocks_MatchesPerBlock verifies that the pipelined CTR keystream is byte-identical to the
+// per-block reference and advances V identically, across partial blocks, consecutive requests, and wraparound.
+func Test_DRBG_FillBlocks_MatchesPerBlock(t *testing.T) {
+	t.Parallel()
+
+	sizes := []int{1, 15, 16, 17, 31, 100, 127, 128, 129, 4096, 4097, 65536}
+	starts := map[string][16]byte{
+		"Zero":     {},
+		"Random":   {0x3a, 0x91, 0x0c, 0x55, 0xde, 0x17, 0x80, 0x42, 0x6b, 0xf0, 0x29, 0xc4, 0x7e, 0x03, 0xb8, 0xa1},
+		"LowCarry": {15: 0xfe, 14: 0xff, 13: 0xff, 12: 0xff, 11: 0xff, 10: 0xff, 9: 0xff, 8: 0xff},
+		"Wrap":     {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfd},
+	}
+
+	for _, zeroBuf := range []bool{false, true} {
+		for name, start := range starts {
+			zeroBuf, name, start := zeroBuf, name, start
+			t.Run(fmt.Sprintf("%s_ZeroBuffer=%v", name, zeroBuf), func(t *testing.T) {
+				t.Parallel()
+				is := assert.New(t)
+
+				cfg := DefaultConfig()
+				cfg.UseZeroBuffer = zeroBuf
+				d, err := newDRBG(&cfg)
+				is.NoError(err)
+				st := d.state
+
+				got, want := start, start
+				for _, n := range sizes {
+					// Consecutive requests continue the cached stream.
+					gotBuf := make([]byte, n)
+					wantBuf := make([]byte, n)
+					d.fillBlocks(gotBuf, st, &got)
+					fillBlocksPerBlock(wantBuf, st, &want)
+					is.Equal(wantBuf, gotBuf, "keystream mismatch for %d bytes", n)
+					is.Equal(want, got, "counter mismatch after %d bytes", n)
+				}
+
+				// A counter that does not match the cached stream position starts a new stream.
+				got, want = start, start
+				gotBuf := make([]byte, 48)
+				wantBuf := make([]byte, 48)
+				d.fillBlocks(gotBuf, st, &got)
+				fillBlocksPerBlock(wantBuf, st, &want)
+				is.Equal(wantBuf, gotBuf)
+			})
+		}
+	}
+}
+
+// Test_addV verifies multi-block counter addition against repeated incV, including carry and wraparound.
+func Test_addV(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	for _, n := range []uint64{0, 1, 2, 255, 256, 4096} {
+		v := [16]byte{7: 0xff, 8: 0xff, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xf0}
+		want := v
+		for i := uint64(0); i < n; i++ {
+			incV(&want)
+		}
+		addV(&v, n)
+		is.Equal(want, v, "addV(%d)", n)
+	}
+
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	addV(&max, 1)
+	is.Equal([16]byte{}, max, "counter should wrap to zero")
+}
+
 // Test_DRBG_ReseedInterval ensures that the DRBG reseeds itself after the configured interval.
 func Test_DRBG_ReseedInterval(t *testing.T) {
 	t.Parallel()
@@ -636,9 +835,50 @@ func Test_CTRDRBG_InitShardPools_Failure(t *testing.T) {
 
 	pools, err := initShardPools(cfg)
 	is.Error(err, "initShardPools should return error on initialization failure")
+	is.ErrorIs(err, ErrInstantiationFailed)
 	is.Nil(pools, "pools should be nil when initialization fails")
 }
 
+// Test_LazyReader verifies that the package-level Reader reports initialization failures as errors,
+// retries on the next call, and can be closed before first use.
+func Test_LazyReader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &toggleSource{}
+	src.fail.Store(true)
+	l := &lazyReader{opts: []Option{WithEntropySource(src), WithShards(1)}}
+
+	buf := make([]byte, 32)
+	_, err := l.Read(buf)
+	var ie *InstantiationError
+	is.ErrorAs(err, &ie)
+	is.ErrorIs(err, errToggleSource)
+	is.Nil(l.r.Load())
+	is.Equal(DefaultConfig().KeySize, l.Config().KeySize)
+	is.Empty(l.Stats().Shards)
+
+	src.fail.Store(false)
+	n, err := l.Read(buf)
+	is.NoError(err)
+	is.Equal(32, n)
+	is.NotNil(l.r.Load(), "a successful call should initialize the Reader")
+	is.Equal(1, l.Config().Shards)
+	is.Equal(uint64(1), l.Stats().Total.Requests)
+
+	is.NoError(l.Close())
+	_, err = l.Read(buf)
+	is.ErrorIs(err, ErrClosed)
+
+	unused := &lazyReader{}
+	is.NoError(unused.Close())
+	_, err = unused.Read(buf)
+	is.ErrorIs(err, ErrClosed, "a Reader closed before first use should never be initialized")
+	is.Nil(unused.r.Load())
+
+	is.IsType(&lazyReader{}, Reader, "the package Reader should be lazily initialized")
+}
+
 // Test_DRBG_Read_MaxRequestSize verifies that Read rejects requests exceeding 64 KB.
 func Test_DRBG_Read_MaxRequestSize(t *testing.T) {
 	t.Parallel()
@@ -702,7 +942,7 @@ func Test_AsyncRekey_WithZeroization_Enabled(t *testing.T) {
 	is.NoError(err)
 
 	// Capture the initial state
-	initialState := d.state.Load()
+	initialState := d.state
 	is.NotNil(initialState)
 
 	// Trigger rekey by exceeding MaxBytesPerKey
@@ -710,24 +950,10 @@ func Test_AsyncRekey_WithZeroization_Enabled(t *testing.T) {
 	_, err = d.Read(buf)
 	is.NoError(err)
 
-	// Wait for async rekey to complete
-	wait := time.NewTimer(500 * time.Millisecond)
-	tick := time.NewTicker(10 * time.Millisecond)
-	defer wait.Stop()
-	defer tick.Stop()
-
-	rekeyCompleted := false
-	for !rekeyCompleted {
-		select {
-		case <-tick.C:
-			currState := d.state.Load()
-			if currState != initialState && atomic.LoadUint64(&d.usage) == 0 {
-				rekeyCompleted = true
-			}
-		case <-wait.C:
-			t.Fatal("Timed out waiting for asyncRekey to complete")
-		}
-	}
+	// Wait for async rekey to complete and apply it.
+	awaitRekey(t, d)
+	is.NotSame(initialState, d.state)
+	is.Zero(d.usage)
 
 	// Verify old state key was zeroized
 	allZero := true
@@ -767,7 +993,7 @@ func Test_AsyncRekey_WithZeroization_Disabled(t *testing.T) {
 	is.NoError(err)
 
 	// Capture the initial state
-	initialState := d.state.Load()
+	initialState := d.state
 	is.NotNil(initialState)
 
 	// Save a copy of the original key to compare after rekey
@@ -778,24 +1004,10 @@ func Test_AsyncRekey_WithZeroization_Disabled(t *testing.T) {
 	_, err = d.Read(buf)
 	is.NoError(err)
 
-	// Wait for async rekey to complete
-	wait := time.NewTimer(500 * time.Millisecond)
-	tick := time.NewTicker(10 * time.Millisecond)
-	defer wait.Stop()
-	defer tick.Stop()
-
-	rekeyCompleted := false
-	for !rekeyCompleted {
-		select {
-		case <-tick.C:
-			currState := d.state.Load()
-			if currState != initialState && atomic.LoadUint64(&d.usage) == 0 {
-				rekeyCompleted = true
-			}
-		case <-wait.C:
-			t.Fatal("Timed out waiting for asyncRekey to complete")
-		}
-	}
+	// Wait for async rekey to complete and apply it.
+	awaitRekey(t, d)
+	is.NotSame(initialState, d.state)
+	is.Zero(d.usage)
 
 	// Verify old state key was NOT zeroized (should still have original value)
 	is.Equal(originalKey, initialState.key, "Old key should NOT be zeroized when EnableZeroization is false")
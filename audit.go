@@ -0,0 +1,345 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrAuditChainBroken is returned by VerifyAuditChain when a record does not follow its predecessor or its
+// hash does not match its contents, indicating that records were modified, removed, inserted, or reordered.
+var ErrAuditChainBroken = errors.New("ctrdrbg: audit chain broken")
+
+// AuditEvent identifies the kind of security event recorded in an AuditRecord.
+type AuditEvent string
+
+const (
+	// AuditInstantiate records the instantiation of a DRBG instance (NIST SP 800-90A §9.1).
+	AuditInstantiate AuditEvent = "instantiate"
+
+	// AuditReseed records a successful reseed (NIST SP 800-90A §9.2); AuditRecord.Cause gives the reason.
+	AuditReseed AuditEvent = "reseed"
+
+	// AuditRekey records a successful key rotation.
+	AuditRekey AuditEvent = "rekey"
+
+	// AuditUpdate records the incorporation of additional input into an RBG1 instance by the
+	// CTR_DRBG_Update function (NIST SP 800-90A §10.2.1.2), which replaces the state without new entropy.
+	AuditUpdate AuditEvent = "update"
+
+	// AuditZeroize records the uninstantiation and zeroization of an instance (NIST SP 800-90A §9.4): by Close,
+	// or when a shard retires an instance returned while it holds more than its maximum number of instances.
+	AuditZeroize AuditEvent = "zeroize"
+)
+
+// AuditRecord is one entry of the audit trail. It describes a security event and commits, through
+// PrevHash, to every record before it.
+//
+// A record contains no secret state: only identifiers, the event, and the name of the entropy source.
+type AuditRecord struct {
+	// Seq is the position of the record in the chain, starting at 1.
+	Seq uint64 `json:"seq"`
+
+	// Time is when the event occurred, in UTC.
+	Time time.Time `json:"time"`
+
+	// Event is the kind of event.
+	Event AuditEvent `json:"event"`
+
+	// Shard is the index of the instance's shard.
+	Shard int `json:"shard"`
+
+	// Instance identifies the instance within its shard, numbered from 1 in order of instantiation.
+	Instance uint64 `json:"instance"`
+
+	// Cause is the ReseedCause of an AuditReseed event, and empty otherwise.
+	Cause string `json:"cause,omitempty"`
+
+	// EntropySource is the name of the entropy source that supplied the seed of an AuditInstantiate,
+	// AuditReseed, or AuditRekey event, and empty otherwise. For a source that draws on others, such as
+	// an EntropyChain, it names the member that served the read (see SourceReporter).
+	EntropySource string `json:"entropy_source,omitempty"`
+
+	// PrevHash is the hex-encoded Hash of the previous record, or 64 zeros for the first record.
+	PrevHash string `json:"prev_hash"`
+
+	// Hash is the hex-encoded SHA-256 digest of PrevHash and the record's other fields (see computeHash).
+	Hash string `json:"hash"`
+}
+
+// genesisHash is the PrevHash of the first record of a chain.
+var genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// computeHash returns the hex-encoded SHA-256 digest committing to the record's fields and PrevHash.
+//
+// Fields are encoded in a fixed order, with variable-length fields length-prefixed, so that the digest
+// does not depend on the serialization format of the sink.
+func (rec *AuditRecord) computeHash() string {
+	h := sha256.New()
+	var n [8]byte
+	writeUint := func(v uint64) {
+		binary.BigEndian.PutUint64(n[:], v)
+		h.Write(n[:])
+	}
+	writeString := func(s string) {
+		writeUint(uint64(len(s)))
+		h.Write([]byte(s))
+	}
+
+	writeString(rec.PrevHash)
+	writeUint(rec.Seq)
+	writeUint(uint64(rec.Time.UnixNano()))
+	writeString(string(rec.Event))
+	writeUint(uint64(rec.Shard))
+	writeUint(rec.Instance)
+	writeString(rec.Cause)
+	writeString(rec.EntropySource)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AuditSink stores the records of an AuditLog. Implementations need not be safe for concurrent use;
+// AuditLog serializes its calls.
+//
+// MemoryAuditSink and FileAuditSink are provided.
+type AuditSink interface {
+	// Append stores rec after every record appended before it.
+	Append(rec AuditRecord) error
+
+	// Last returns the most recently stored record, so that an AuditLog can continue an existing chain.
+	// It returns false if the sink is empty.
+	Last() (AuditRecord, bool)
+}
+
+// AuditLog records DRBG security events to an AuditSink as a hash chain: each record carries the hash of
+// its predecessor, so any modification, deletion, insertion, or reordering of stored records is detected
+// by VerifyAuditChain.
+//
+// Install an AuditLog with WithAuditLog. An AuditLog is safe for concurrent use; its records identify
+// instances by shard and instance number, so use a separate AuditLog for each Reader.
+type AuditLog struct {
+	mu   sync.Mutex
+	sink AuditSink
+	seq  uint64
+	prev string
+	err  error
+}
+
+// NewAuditLog returns an AuditLog that appends to sink, continuing the chain of any records it already holds.
+func NewAuditLog(sink AuditSink) *AuditLog {
+	a := &AuditLog{sink: sink, prev: genesisHash}
+	if last, ok := sink.Last(); ok {
+		a.seq = last.Seq
+		a.prev = last.Hash
+	}
+	return a
+}
+
+// Err returns the first error returned by the sink, if any. Records that could not be stored leave a gap
+// in the chain, which VerifyAuditChain reports.
+func (a *AuditLog) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// Head returns the sequence number and hash of the latest record, which commit to the entire chain.
+// It returns 0 and the genesis hash (64 zeros) before the first record.
+func (a *AuditLog) Head() (uint64, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.seq, a.prev
+}
+
+// record appends a record of event to the chain.
+func (a *AuditLog) record(event AuditEvent, shard int, instance uint64, cause, source string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.seq++
+	rec := AuditRecord{
+		Seq:           a.seq,
+		Time:          time.Now().UTC(),
+		Event:         event,
+		Shard:         shard,
+		Instance:      instance,
+		Cause:         cause,
+		EntropySource: source,
+		PrevHash:      a.prev,
+	}
+	rec.Hash = rec.computeHash()
+	a.prev = rec.Hash
+
+	if err := a.sink.Append(rec); err != nil && a.err == nil {
+		a.err = err
+	}
+}
+
+// VerifyAuditChain verifies that records form a complete hash chain: the first record starts the chain,
+// each subsequent record follows its predecessor, and every hash matches its record's contents.
+//
+// Returns nil if the chain is intact, or an error wrapping ErrAuditChainBroken that identifies the first
+// offending record.
+//
+// Records removed from the end of a chain leave a shorter chain that is still intact. To detect this,
+// keep the latest Seq and Hash (see AuditLog.Head) somewhere the sink's storage cannot alter, and compare
+// them with the last record.
+func VerifyAuditChain(records []AuditRecord) error {
+	prev := genesisHash
+	for i := range records {
+		rec := &records[i]
+		switch {
+		case rec.Seq != uint64(i)+1:
+			return fmt.Errorf("%w: record %d has sequence number %d", ErrAuditChainBroken, i+1, rec.Seq)
+		case rec.PrevHash != prev:
+			return fmt.Errorf("%w: record %d does not follow its predecessor", ErrAuditChainBroken, rec.Seq)
+		case rec.computeHash() != rec.Hash:
+			return fmt.Errorf("%w: record %d does not match its hash", ErrAuditChainBroken, rec.Seq)
+		}
+		prev = rec.Hash
+	}
+	return nil
+}
+
+// ReadAuditRecords reads the JSON Lines audit records written by a FileAuditSink.
+func ReadAuditRecords(r io.Reader) ([]AuditRecord, error) {
+	var records []AuditRecord
+	dec := json.NewDecoder(r)
+	for {
+		var rec AuditRecord
+		if err := dec.Decode(&rec); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return records, fmt.Errorf("ctrdrbg: reading audit record %d: %w", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// MemoryAuditSink is an AuditSink that keeps records in memory, for tests and for callers that ship
+// records elsewhere themselves. It is safe for concurrent use.
+type MemoryAuditSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+// NewMemoryAuditSink returns an empty MemoryAuditSink.
+func NewMemoryAuditSink() *MemoryAuditSink {
+	return &MemoryAuditSink{}
+}
+
+// Append implements AuditSink.
+func (s *MemoryAuditSink) Append(rec AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+// Last implements AuditSink.
+func (s *MemoryAuditSink) Last() (AuditRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.records) == 0 {
+		return AuditRecord{}, false
+	}
+	return s.records[len(s.records)-1], true
+}
+
+// Records returns a copy of the stored records, in order.
+func (s *MemoryAuditSink) Records() []AuditRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]AuditRecord(nil), s.records...)
+}
+
+// FileAuditSink is an AuditSink that appends records to a file as JSON Lines, one record per line.
+//
+// Records are written with a single write call each but are not synced; call Sync to flush them to stable
+// storage. Use ReadAuditRecords and VerifyAuditChain to verify the file.
+type FileAuditSink struct {
+	f    *os.File
+	last AuditRecord
+	ok   bool
+}
+
+// OpenAuditFile opens (or creates, with mode 0600) the audit file at path for appending, and returns a
+// FileAuditSink that continues its chain.
+//
+// The existing records are verified first. Returns an error wrapping ErrAuditChainBroken if they do not
+// form an intact chain, so that new records are never appended to a tampered trail.
+func OpenAuditFile(path string) (*FileAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := ReadAuditRecords(bufio.NewReader(f))
+	if err == nil {
+		err = VerifyAuditChain(records)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	s := &FileAuditSink{f: f}
+	if len(records) > 0 {
+		s.last, s.ok = records[len(records)-1], true
+	}
+	return s, nil
+}
+
+// Append implements AuditSink.
+func (s *FileAuditSink) Append(rec AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := s.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	s.last, s.ok = rec, true
+	return nil
+}
+
+// Last implements AuditSink.
+func (s *FileAuditSink) Last() (AuditRecord, bool) {
+	return s.last, s.ok
+}
+
+// Sync commits the records written so far to stable storage.
+func (s *FileAuditSink) Sync() error {
+	return s.f.Sync()
+}
+
+// Close closes the file. Close the Readers using the sink first.
+func (s *FileAuditSink) Close() error {
+	return s.f.Close()
+}
+
+// auditEvent records event for d to the configured AuditLog, if any.
+func (d *drbg) auditEvent(event AuditEvent, cause string) {
+	a := d.config.AuditLog
+	if a == nil {
+		return
+	}
+	var source string
+	switch event {
+	case AuditInstantiate, AuditReseed, AuditRekey:
+		source = d.seedSource
+	}
+	a.record(event, d.config.shard, d.instance, cause, source)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_AuditLog_Reader verifies the audit trail recorded over a Reader's lifetime.
+func Test_AuditLog_Reader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	sink := NewMemoryAuditSink()
+	audit := NewAuditLog(sink)
+	r, err := NewReader(WithShards(1), WithAuditLog(audit), WithReseedRequests(2),
+		WithEnableKeyRotation(true), WithMaxBytesPerKey(64), WithRekeyMode(RekeyModeSync))
+	is.NoError(err)
+
+	s, err := r.Acquire()
+	is.NoError(err)
+	key := hex.EncodeToString(s.d.state.key[:])
+	buf := make([]byte, 32)
+	for i := 0; i < 3; i++ {
+		_, err = s.Read(buf)
+		is.NoError(err)
+	}
+	s.Release()
+	is.NoError(r.Reseed(nil))
+	is.NoError(r.Close())
+
+	records := sink.Records()
+	var events []string
+	for _, rec := range records {
+		events = append(events, string(rec.Event)+":"+rec.Cause)
+		is.Zero(rec.Shard)
+		is.Equal(uint64(1), rec.Instance)
+		if rec.Event == AuditZeroize {
+			is.Empty(rec.EntropySource)
+		} else {
+			is.Equal("crypto/rand", rec.EntropySource)
+		}
+	}
+	is.Equal([]string{"instantiate:", "rekey:", "reseed:request_count", "reseed:manual", "zeroize:"}, events)
+	is.NoError(VerifyAuditChain(records))
+
+	seq, head := audit.Head()
+	is.Equal(uint64(len(records)), seq)
+	is.Equal(records[len(records)-1].Hash, head)
+	is.NoError(audit.Err())
+
+	out, err := json.Marshal(records)
+	is.NoError(err)
+	is.NotContains(string(out), key, "records must not contain key material")
+}
+
+// Test_AuditLog_ChainSource verifies that records name the chain member that served each seed.
+func Test_AuditLog_ChainSource(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	backup := &toggleSource{name: "backup"}
+	chain, err := NewEntropyChain([]ChainSource{{Source: primary}, {Source: backup}})
+	is.NoError(err)
+
+	sink := NewMemoryAuditSink()
+	r, err := NewReader(WithShards(1), WithEntropySource(chain), WithAuditLog(NewAuditLog(sink)))
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = r.Read(buf)
+	is.NoError(err)
+	primary.fail.Store(true)
+	is.NoError(r.Reseed(nil))
+	_, err = r.Read(buf)
+	is.NoError(err)
+	is.NoError(r.Close())
+
+	var sources []string
+	for _, rec := range sink.Records() {
+		if rec.Event != AuditZeroize {
+			sources = append(sources, string(rec.Event)+":"+rec.EntropySource)
+		}
+	}
+	is.Equal([]string{"instantiate:primary", "reseed:backup"}, sources)
+}
+
+// Test_AuditLog_RBG1Update verifies that the RBG1 update function is recorded, without an entropy source.
+func Test_AuditLog_RBG1Update(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	sink := NewMemoryAuditSink()
+	r, err := NewReader(WithShards(1), WithConstruction(ConstructionRBG1),
+		WithEntropySource(&kindSource{kind: SourceKindRBG}), WithAuditLog(NewAuditLog(sink)))
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = r.ReadWithAdditionalInput(buf, []byte("additional input"))
+	is.NoError(err)
+	is.NoError(r.Close())
+
+	records := sink.Records()
+	var events []string
+	for _, rec := range records {
+		events = append(events, string(rec.Event)+":"+rec.EntropySource)
+	}
+	is.Equal([]string{"instantiate:counting", "update:", "zeroize:"}, events)
+	is.NoError(VerifyAuditChain(records))
+}
+
+// Test_VerifyAuditChain verifies that modified, removed, and reordered records are detected.
+func Test_VerifyAuditChain(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	sink := NewMemoryAuditSink()
+	audit := NewAuditLog(sink)
+	for i := 0; i < 4; i++ {
+		audit.record(AuditReseed, 0, 1, ReseedCauseManual.String(), "test")
+	}
+	records := sink.Records()
+	is.NoError(VerifyAuditChain(records))
+	is.NoError(VerifyAuditChain(nil))
+
+	testCases := []struct {
+		name   string
+		tamper func([]AuditRecord) []AuditRecord
+	}{
+		{"Modified", func(rs []AuditRecord) []AuditRecord { rs[1].Cause = "fork"; return rs }},
+		{"Rehashed", func(rs []AuditRecord) []AuditRecord {
+			rs[1].Shard = 3
+			rs[1].Hash = rs[1].computeHash()
+			return rs
+		}},
+		{"Removed", func(rs []AuditRecord) []AuditRecord { return append(rs[:1], rs[2:]...) }},
+		{"Reordered", func(rs []AuditRecord) []AuditRecord { rs[1], rs[2] = rs[2], rs[1]; return rs }},
+		{"Renumbered", func(rs []AuditRecord) []AuditRecord { rs[0].Seq = 7; return rs }},
+	}
+	for _, tc := range testCases {
+		tampered := tc.tamper(append([]AuditRecord(nil), records...))
+		is.ErrorIs(VerifyAuditChain(tampered), ErrAuditChainBroken, tc.name)
+	}
+}
+
+// Test_OpenAuditFile verifies that a file sink persists a verifiable chain, continues it when reopened,
+// and refuses to append to a tampered file.
+func Test_OpenAuditFile(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for i := 0; i < 2; i++ {
+		sink, err := OpenAuditFile(path)
+		is.NoError(err)
+		r, err := NewReader(WithShards(1), WithAuditLog(NewAuditLog(sink)))
+		is.NoError(err)
+		is.NoError(r.Close())
+		is.NoError(sink.Sync())
+		is.NoError(sink.Close())
+	}
+
+	f, err := os.Open(path)
+	is.NoError(err)
+	records, err := ReadAuditRecords(f)
+	is.NoError(f.Close())
+	is.NoError(err)
+	is.Len(records, 4, "each run should record an instantiation and a zeroization")
+	is.NoError(VerifyAuditChain(records))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		is.NoError(err)
+		is.Equal(os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	is.NoError(err)
+	tampered := strings.Replace(string(data), `"event":"zeroize"`, `"event":"reseed"`, 1)
+	is.NoError(os.WriteFile(path, []byte(tampered), 0o600))
+	_, err = OpenAuditFile(path)
+	is.ErrorIs(err, ErrAuditChainBroken)
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"crypto/fips140"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ComplianceStatus is the outcome of evaluating one requirement in a ComplianceReport.
+type ComplianceStatus string
+
+const (
+	// CompliancePass indicates the requirement is met.
+	CompliancePass ComplianceStatus = "pass"
+
+	// ComplianceWarn indicates the requirement is met, but an optional or recommended measure is not in
+	// effect, or the result depends on the deployment.
+	ComplianceWarn ComplianceStatus = "warn"
+
+	// ComplianceFail indicates the requirement is not met by the configuration.
+	ComplianceFail ComplianceStatus = "fail"
+)
+
+// ComplianceResult is the evaluation of one requirement of the compliance catalogue.
+type ComplianceResult struct {
+	// ID is a stable identifier for the requirement, such as "health-test".
+	ID string `json:"id"`
+
+	// Requirement states the requirement.
+	Requirement string `json:"requirement"`
+
+	// Reference cites the specification section the requirement is drawn from.
+	Reference string `json:"reference"`
+
+	// Status is the outcome for the evaluated configuration.
+	Status ComplianceStatus `json:"status"`
+
+	// Detail explains the outcome in terms of the configuration.
+	Detail string `json:"detail"`
+}
+
+// ComplianceReport is the evaluation of a configuration against the catalogue of SP 800-90A, SP 800-90C,
+// and FIPS 140-3 requirements that depend on configuration or runtime state.
+//
+// It is the source of truth for which configurations are aligned with those standards; the requirements
+// met by construction are documented in docs/NIST-SP-800-90A.md.
+type ComplianceReport struct {
+	// Status is the worst status among Results.
+	Status ComplianceStatus `json:"status"`
+
+	// Results holds one result per catalogue requirement, in catalogue order.
+	Results []ComplianceResult `json:"results"`
+}
+
+// complianceRequirement is one entry of the compliance catalogue.
+type complianceRequirement struct {
+	id          string
+	requirement string
+	reference   string
+
+	// criteria summarizes how the requirement is evaluated, for the catalogue documentation.
+	criteria string
+
+	// evaluate returns the status and detail for cfg.
+	evaluate func(cfg *Config) (ComplianceStatus, string)
+}
+
+// complianceCatalogue lists the evaluated requirements, in specification order.
+var complianceCatalogue = []complianceRequirement{
+	{
+		id:          "fork-safety",
+		requirement: "The internal state is not duplicated across processes",
+		reference:   "SP 800-90A §8.3",
+		criteria:    "fail if ForkDetectionInterval is nonzero (forks are detected late); pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if cfg.ForkDetectionInterval != 0 {
+				return ComplianceFail, fmt.Sprintf("fork detection runs every %d requests; a forked child may "+
+					"repeat its parent's output until the next check", cfg.ForkDetectionInterval)
+			}
+			return CompliancePass, "fork detection runs on every request"
+		},
+	},
+	{
+		id:          "entropy-source",
+		requirement: "Seeds are drawn from an entropy source credited with full entropy",
+		reference:   "SP 800-90A §8.6.5",
+		criteria:    "fail if EntropySource is not credited with full entropy; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			src := cfg.entropySource()
+			if !isCredited(src) {
+				return ComplianceFail, fmt.Sprintf("%s is not credited with full entropy", src.Name())
+			}
+			return CompliancePass, fmt.Sprintf("%s (%s)", src.Name(), sourceKind(src))
+		},
+	},
+	{
+		id:          "personalization",
+		requirement: "A personalization string is used at instantiation",
+		reference:   "SP 800-90A §8.7.1",
+		criteria:    "warn if Personalization is empty (recommended, not required); pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if len(cfg.Personalization) == 0 {
+				return ComplianceWarn, "no personalization string; recommended for domain separation"
+			}
+			return CompliancePass, "personalization string set"
+		},
+	},
+	{
+		id:          "prediction-resistance",
+		requirement: "Output is generated with prediction resistance",
+		reference:   "SP 800-90A §9.3",
+		criteria:    "warn if PredictionResistance is disabled (optional); pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if !cfg.PredictionResistance {
+				return ComplianceWarn, "prediction resistance disabled; requests cannot be served with prediction resistance"
+			}
+			return CompliancePass, "every request is preceded by a reseed"
+		},
+	},
+	{
+		id:          "uninstantiate",
+		requirement: "Instances can be uninstantiated and their internal state zeroized",
+		reference:   "SP 800-90A §9.4",
+		criteria:    "always pass (Close)",
+		evaluate: func(*Config) (ComplianceStatus, string) {
+			return CompliancePass, "Close zeroizes every instance"
+		},
+	},
+	{
+		id:          "security-strength",
+		requirement: "The key size provides the required security strength",
+		reference:   "SP 800-90A §10.2.1 (Table 3)",
+		criteria:    "fail if the key size is below the minimum strength of Construction; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			s := cfg.KeySize.SecurityStrength()
+			if m := cfg.Construction.MinSecurityStrength(); s < m {
+				return ComplianceFail, fmt.Sprintf("AES-%d provides %d bits; %s requires %d", s, s, cfg.Construction, m)
+			}
+			return CompliancePass, fmt.Sprintf("AES-%d, %d-bit security strength", s, s)
+		},
+	},
+	{
+		id:          "max-request",
+		requirement: "Each request returns at most max_number_of_bits_per_request",
+		reference:   "SP 800-90A §10.2.1 (Table 3)",
+		criteria:    "always pass (enforced)",
+		evaluate: func(*Config) (ComplianceStatus, string) {
+			return CompliancePass, fmt.Sprintf("requests are limited to %d bytes", MaxBytesPerRequest)
+		},
+	},
+	{
+		id:          "reseed-interval",
+		requirement: "The reseed counter is enforced, with reseed_interval at most 2^48 requests",
+		reference:   "SP 800-90A §10.2.1 (Table 3)",
+		criteria:    "fail if neither PredictionResistance nor ReseedRequests is set; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			switch {
+			case cfg.PredictionResistance:
+				return CompliancePass, "every request is preceded by a reseed"
+			case cfg.ReseedRequests > 0:
+				return CompliancePass, fmt.Sprintf("reseed every %d requests", cfg.ReseedRequests)
+			}
+			return ComplianceFail, "ReseedRequests is 0; the reseed counter is not enforced"
+		},
+	},
+	{
+		id:          "health-test",
+		requirement: "Output is continuously health tested",
+		reference:   "SP 800-90A §11.3.3",
+		criteria:    "fail if ContinuousHealthTest is disabled; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if !cfg.ContinuousHealthTest {
+				return ComplianceFail, "continuous health test disabled"
+			}
+			return CompliancePass, "each output block is compared with the previous one"
+		},
+	},
+	{
+		id:          "construction",
+		requirement: "An RBG construction is selected and enforced",
+		reference:   "SP 800-90C §4",
+		criteria:    "warn if Construction is unspecified; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if cfg.Construction == ConstructionUnspecified {
+				return ComplianceWarn, "no SP 800-90C construction enforced"
+			}
+			return CompliancePass, cfg.Construction.String()
+		},
+	},
+	{
+		id:          "fips-mode",
+		requirement: "Cryptographic algorithms are provided by a FIPS 140-3 validated module",
+		reference:   "FIPS 140-3",
+		criteria:    "warn if Go's FIPS 140-3 mode is off (GODEBUG=fips140=on); pass otherwise",
+		evaluate: func(*Config) (ComplianceStatus, string) {
+			if !fips140.Enabled() {
+				return ComplianceWarn, "Go's FIPS 140-3 mode is off"
+			}
+			return CompliancePass, "Go Cryptographic Module in FIPS 140-3 mode"
+		},
+	},
+	{
+		id:          "zeroization",
+		requirement: "Replaced key material is zeroized",
+		reference:   "FIPS 140-3 (ISO/IEC 19790 §7.9.7)",
+		criteria:    "fail if EnableZeroization is disabled; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if !cfg.EnableZeroization {
+				return ComplianceFail, "old keys are overwritten, not zeroized"
+			}
+			return CompliancePass, "old keys and counters are zeroized on reseed and rekey"
+		},
+	},
+	{
+		id:          "self-tests",
+		requirement: "Known Answer Tests pass before first use",
+		reference:   "FIPS 140-3 (ISO/IEC 19790 §7.10)",
+		criteria:    "fail if EnableSelfTests is disabled or the tests failed in NewReader; warn if they have not run yet; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			switch {
+			case !cfg.EnableSelfTests:
+				return ComplianceFail, "self-tests disabled"
+			case cfg.selfTest == nil:
+				return ComplianceWarn, "AES-CTR Known Answer Test will run in NewReader"
+			case cfg.selfTest.Err != nil:
+				return ComplianceFail, cfg.selfTest.Err.Error()
+			}
+			return CompliancePass, fmt.Sprintf("AES-CTR Known Answer Test passed in NewReader (%s)", cfg.selfTest.Duration)
+		},
+	},
+	{
+		id:          "rekey-failure",
+		requirement: "Output stops or a reseed is forced when key rotation fails",
+		reference:   "Implementation policy",
+		criteria:    "warn if key rotation is enabled with RekeyFailureContinue; pass otherwise",
+		evaluate: func(cfg *Config) (ComplianceStatus, string) {
+			if !cfg.EnableKeyRotation {
+				return CompliancePass, "key rotation disabled"
+			}
+			if cfg.RekeyFailurePolicy == RekeyFailureContinue {
+				return ComplianceWarn, "output continues under an exhausted key if rotation fails"
+			}
+			return CompliancePass, fmt.Sprintf("rekey failure policy %s", cfg.RekeyFailurePolicy)
+		},
+	},
+}
+
+// statusRank orders statuses from best to worst.
+func statusRank(s ComplianceStatus) int {
+	switch s {
+	case CompliancePass:
+		return 0
+	case ComplianceWarn:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EvaluateCompliance evaluates cfg against the compliance catalogue. It is the function behind
+// Interface.ComplianceReport, and may be used to check a configuration before constructing a Reader.
+//
+// Requirements that depend on the runtime, such as Go's FIPS 140-3 mode, are evaluated in the calling
+// process. The self-test requirement reports the result recorded by NewReader; for a Config that has not
+// been passed to NewReader, it warns that the tests have not run yet, or fails if they are disabled.
+func EvaluateCompliance(cfg Config) ComplianceReport {
+	report := ComplianceReport{Status: CompliancePass, Results: make([]ComplianceResult, len(complianceCatalogue))}
+	for i, req := range complianceCatalogue {
+		status, detail := req.evaluate(&cfg)
+		report.Results[i] = ComplianceResult{
+			ID:          req.id,
+			Requirement: req.requirement,
+			Reference:   req.reference,
+			Status:      status,
+			Detail:      detail,
+		}
+		if statusRank(status) > statusRank(report.Status) {
+			report.Status = status
+		}
+	}
+	return report
+}
+
+// ComplianceReport evaluates the reader's configuration against the compliance catalogue.
+func (r *reader) ComplianceReport() ComplianceReport {
+	return EvaluateCompliance(r.Config())
+}
+
+// JSON returns the report as indented JSON.
+func (r ComplianceReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown returns the report as a Markdown table, preceded by the overall status.
+func (r ComplianceReport) Markdown() string {
+	var b strings.Builder
+	counts := map[ComplianceStatus]int{}
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	fmt.Fprintf(&b, "**Overall status: %s** (%d pass, %d warn, %d fail)\n\n", r.Status,
+		counts[CompliancePass], counts[ComplianceWarn], counts[ComplianceFail])
+	b.WriteString("| ID | Requirement | Reference | Status | Detail |\n")
+	b.WriteString("|----|-------------|-----------|--------|--------|\n")
+	for _, res := range r.Results {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", res.ID, escapeMarkdownCell(res.Requirement),
+			res.Reference, res.Status, escapeMarkdownCell(res.Detail))
+	}
+	return b.String()
+}
+
+// complianceCatalogueMarkdown returns the catalogue, without results, as a Markdown table. It is embedded
+// in docs/NIST-SP-800-90A.md, which a test keeps in sync.
+func complianceCatalogueMarkdown() string {
+	var b strings.Builder
+	b.WriteString("| ID | Requirement | Reference | Evaluation |\n")
+	b.WriteString("|----|-------------|-----------|------------|\n")
+	for _, req := range complianceCatalogue {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", req.id, req.requirement, req.reference, req.criteria)
+	}
+	return b.String()
+}
+
+// escapeMarkdownCell escapes characters that would break a Markdown table cell.
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resultsByID indexes the results of a report by requirement ID.
+func resultsByID(report ComplianceReport) map[string]ComplianceResult {
+	m := make(map[string]ComplianceResult, len(report.Results))
+	for _, res := range report.Results {
+		m[res.ID] = res
+	}
+	return m
+}
+
+// Test_EvaluateCompliance_Default verifies that the default configuration fails the requirements whose
+// measures are disabled by default.
+func Test_EvaluateCompliance_Default(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	report := EvaluateCompliance(DefaultConfig())
+	is.Len(report.Results, len(complianceCatalogue))
+	is.Equal(ComplianceFail, report.Status)
+
+	res := resultsByID(report)
+	is.Len(res, len(complianceCatalogue), "requirement IDs should be unique")
+	for _, id := range []string{"health-test", "zeroization", "self-tests", "reseed-interval"} {
+		is.Equal(ComplianceFail, res[id].Status, id)
+	}
+	for _, id := range []string{"personalization", "prediction-resistance", "construction"} {
+		is.Equal(ComplianceWarn, res[id].Status, id)
+	}
+	for _, id := range []string{"fork-safety", "entropy-source", "uninstantiate", "security-strength",
+		"max-request", "rekey-failure"} {
+		is.Equal(CompliancePass, res[id].Status, id)
+	}
+	for _, r := range report.Results {
+		is.NotEmpty(r.Requirement, r.ID)
+		is.NotEmpty(r.Reference, r.ID)
+		is.NotEmpty(r.Detail, r.ID)
+	}
+}
+
+// Test_EvaluateCompliance_Hardened verifies that a configuration enabling every measure has no failures or
+// warnings other than those that depend on the runtime.
+func Test_EvaluateCompliance_Hardened(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	for _, opt := range []Option{
+		WithPersonalization([]byte("service-A")),
+		WithPredictionResistance(true),
+		WithSelfTests(true),
+		WithZeroization(true),
+		WithContinuousHealthTest(true),
+		WithConstruction(ConstructionRBG2NP),
+		WithEnableKeyRotation(true),
+		WithRekeyFailurePolicy(RekeyFailureFailClosed),
+	} {
+		opt(&cfg)
+	}
+
+	report := EvaluateCompliance(cfg)
+	for _, r := range report.Results {
+		switch r.ID {
+		case "fips-mode":
+			continue
+		case "self-tests":
+			is.Equal(ComplianceWarn, r.Status, "the tests have not run before NewReader")
+			continue
+		}
+		is.Equal(CompliancePass, r.Status, "%s: %s", r.ID, r.Detail)
+	}
+	is.NotEqual(ComplianceFail, report.Status)
+}
+
+// Test_EvaluateCompliance_Failures verifies individual failing and warning configurations.
+func Test_EvaluateCompliance_Failures(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.ForkDetectionInterval = 100
+	cfg.Construction = ConstructionRBG3XOR
+	cfg.KeySize = KeySize128
+	cfg.EnableKeyRotation = true
+	cfg.RekeyFailurePolicy = RekeyFailureContinue
+	cfg.ReseedRequests = 1000
+
+	res := resultsByID(EvaluateCompliance(cfg))
+	is.Equal(ComplianceFail, res["fork-safety"].Status)
+	is.Contains(res["fork-safety"].Detail, "100")
+	is.Equal(ComplianceFail, res["security-strength"].Status)
+	is.Equal(ComplianceWarn, res["rekey-failure"].Status)
+	is.Equal(CompliancePass, res["reseed-interval"].Status)
+	is.Contains(res["reseed-interval"].Detail, "1000")
+}
+
+// Test_Reader_ComplianceReport verifies that a Reader reports on its effective configuration, including
+// after Close, and that the default Reader does not initialize to produce a report.
+func Test_Reader_ComplianceReport(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(1), WithContinuousHealthTest(true))
+	is.NoError(err)
+	is.NoError(r.Close())
+
+	res := resultsByID(r.ComplianceReport())
+	is.Equal(CompliancePass, res["health-test"].Status)
+	is.Equal(ComplianceFail, res["zeroization"].Status)
+
+	l := &lazyReader{}
+	is.Equal(EvaluateCompliance(DefaultConfig()).Status, l.ComplianceReport().Status)
+	is.Nil(l.r.Load())
+}
+
+// Test_Reader_ComplianceReport_SelfTests verifies that a Reader reports the self-test result recorded by
+// NewReader rather than running the tests again.
+func Test_Reader_ComplianceReport_SelfTests(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(1), WithSelfTests(true))
+	is.NoError(err)
+	defer func() { _ = r.Close() }()
+
+	cfg := r.Config()
+	is.NotNil(cfg.selfTest)
+	res := resultsByID(r.ComplianceReport())
+	is.Equal(CompliancePass, res["self-tests"].Status)
+	is.Contains(res["self-tests"].Detail, "passed in NewReader")
+
+	cfg.selfTest = &SelfTestEvent{Err: ErrSelfTestFailed}
+	res = resultsByID(EvaluateCompliance(cfg))
+	is.Equal(ComplianceFail, res["self-tests"].Status)
+	is.Equal(ErrSelfTestFailed.Error(), res["self-tests"].Detail)
+
+	cfg = DefaultConfig()
+	cfg.EnableSelfTests = true
+	res = resultsByID(EvaluateCompliance(cfg))
+	is.Equal(ComplianceWarn, res["self-tests"].Status, "no evidence before NewReader runs the tests")
+	is.Equal("AES-CTR Known Answer Test will run in NewReader", res["self-tests"].Detail)
+}
+
+// Test_ComplianceReport_Formats verifies the JSON and Markdown renderings.
+func Test_ComplianceReport_Formats(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	report := EvaluateCompliance(DefaultConfig())
+
+	data, err := report.JSON()
+	is.NoError(err)
+	var decoded ComplianceReport
+	is.NoError(json.Unmarshal(data, &decoded))
+	is.Equal(report, decoded)
+	is.Contains(string(data), `"id": "health-test"`)
+	is.Contains(string(data), `"status": "fail"`)
+
+	md := report.Markdown()
+	is.True(strings.HasPrefix(md, "**Overall status: fail**"))
+	is.Equal(len(report.Results)+4, strings.Count(md, "\n"), "status, blank line, header, separator, and rows")
+	is.Contains(md, "| `health-test` | Output is continuously health tested | SP 800-90A §11.3.3 | fail |")
+
+	is.Equal(`a\|b c`, escapeMarkdownCell("a|b\nc"))
+}
+
+// Test_ComplianceCatalogue_Docs verifies that the catalogue in docs/NIST-SP-800-90A.md matches
+// complianceCatalogue.
+func Test_ComplianceCatalogue_Docs(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	const begin, end = "<!-- BEGIN compliance catalogue -->\n", "<!-- END compliance catalogue -->"
+	data, err := os.ReadFile("docs/NIST-SP-800-90A.md")
+	is.NoError(err)
+
+	doc := string(data)
+	i, j := strings.Index(doc, begin), strings.Index(doc, end)
+	if !is.True(i >= 0 && j > i, "catalogue markers not found") {
+		return
+	}
+	is.Equal(complianceCatalogueMarkdown(), doc[i+len(begin):j],
+		"docs/NIST-SP-800-90A.md is out of date; replace the catalogue with complianceCatalogueMarkdown()")
+}
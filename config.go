@@ -12,10 +12,18 @@
 package ctrdrbg
 
 import (
+	"crypto/fips140"
+	"errors"
+	"fmt"
+	"log/slog"
 	"runtime"
 	"time"
 )
 
+// ErrInvalidConfig is wrapped by the errors Config.Validate (and so NewReader) reports for settings that
+// are out of range or inconsistent with each other.
+var ErrInvalidConfig = errors.New("ctrdrbg: invalid configuration")
+
 // KeySize represents the valid AES key lengths supported by AES-CTR-DRBG.
 //
 // It enforces compile-time type safety for AES key selection and helps
@@ -58,7 +66,30 @@ const (
 //   - MaxRekeyBackoff: Maximum backoff duration for exponential rekey retries.
 //   - RekeyBackoff: Initial backoff for rekey attempts.
 //   - EnableKeyRotation: Whether to enable automatic key rotation (default: true).
+//   - RekeyMode: How the key is rotated once MaxBytesPerKey is reached (default: asynchronous).
+//   - RekeyFailurePolicy: What happens when key rotation exhausts its attempts (default: continue).
+//   - RekeyFailureHandler: Optional callback notified of every key rotation failure.
 //   - Personalization: Optional per-instance byte string for domain separation.
+//   - EntropySource: Source of entropy input for instantiation and reseeding (default: crypto/rand).
+//   - EntropyTimeout: Maximum time NewReader waits for the entropy source to become ready.
+//   - AdditionalInputSource: Optional non-credited source mixed in as additional input (e.g., a beacon).
+//   - Construction: SP 800-90C RBG construction whose requirements are enforced (default: unspecified).
+//   - GenerationIDProvider: Optional VM generation ID source; a change reseeds every instance.
+//   - GenerationIDCheckInterval: Minimum time between generation ID checks.
+//   - ShardStrategy: How a Reader chooses a shard per request (default: per-processor affinity).
+//   - KeystreamCacheSize: Per-instance keystream cache for small reads (default: disabled).
+//   - EntropyPrefetchSize: Per-shard bulk entropy prefetch buffer (default: disabled).
+//   - EntropyPrefetchMaxAge: Maximum staleness of prefetched entropy (default: 1 second).
+//   - ExpvarName: Optional expvar name under which the Reader's Stats are published.
+//   - Logger: Optional structured logger for lifecycle events (default: none).
+//   - Hooks: Optional callbacks for lifecycle events (default: none).
+//   - AuditLog: Optional hash-chained audit trail of security events (default: none).
+//   - Profile: Preset selected with WithProfile, whose constraints Validate enforces (default: none).
+//
+// Call Validate to check a Config; NewReader does so before instantiating anything.
+//
+// Config implements slog.LogValuer: when logged, Personalization is redacted and function and interface
+// fields are reduced to names or presence flags.
 type Config struct {
 	// Personalization provides a per-instance personalization string, which is XOR-ed into the
 	// DRBG’s initial seed to support domain separation or unique generator state.
@@ -78,6 +109,85 @@ type Config struct {
 	// When unset (nil), no personalization is applied.
 	Personalization []byte
 
+	// EntropySource supplies the entropy input used to instantiate and reseed every DRBG instance.
+	//
+	// When nil (default), crypto/rand.Reader is used. Note that in Go's FIPS-140 mode crypto/rand.Reader
+	// is itself a DRBG; on Linux, NewGetrandomSource seeds directly from the kernel via getrandom(2).
+	EntropySource EntropySource
+
+	// EntropyTimeout bounds how long NewReader waits for the entropy source to report readiness.
+	//
+	// Applies only to sources implementing ReadinessChecker (the default source and GetrandomSource do).
+	// If the source is not ready in time, NewReader returns an *EntropyNotReadyError instead of hanging.
+	// Zero (default) disables the readiness wait.
+	EntropyTimeout time.Duration
+
+	// AdditionalInputSource optionally supplies supplemental input (for example, a BeaconSource) that is
+	// mixed into every instantiation and reseed as additional input.
+	//
+	// Its output is never credited as entropy: failures are ignored, and security rests entirely on
+	// EntropySource. When nil (default), no supplemental input is mixed in.
+	AdditionalInputSource EntropySource
+
+	// Construction selects the NIST SP 800-90C RBG construction (RBG1, RBG2(P), RBG2(NP), or RBG3(XOR))
+	// whose security strength and reseed requirements are enforced.
+	//
+	// NewReader returns an error wrapping ErrConstruction if the configuration violates the construction.
+	// The default, ConstructionUnspecified, applies no construction-specific constraints.
+	Construction Construction
+
+	// GenerationIDProvider optionally reports a VM generation ID (for example, vmgenid) that changes when
+	// the process is restored from a VM snapshot or checkpoint, or the VM is cloned.
+	//
+	// Such events keep the same PID and are invisible to fork detection. When the generation ID changes,
+	// every DRBG instance reseeds before producing further output. When nil (default), no check is made.
+	GenerationIDProvider GenerationIDProvider
+
+	// GenerationIDCheckInterval is the minimum time between generation ID checks, shared by all instances
+	// of a Reader.
+	//
+	// The default is 100ms, bounding the window after a restore during which replayed output may be
+	// produced while keeping provider reads, which typically hit the filesystem, off the common read path.
+	// If 0, the generation ID is checked on every output request (max safety), serializing every request
+	// through the provider. It has no effect when GenerationIDProvider is nil.
+	GenerationIDCheckInterval time.Duration
+
+	// EntropyPrefetchSize enables a per-shard entropy prefetch buffer of this many bytes.
+	//
+	// When nonzero, instantiation and reseeds draw entropy from a buffer that is filled by one bulk read of
+	// EntropySource, instead of reading KeySize+16 bytes from the source every time. This keeps
+	// high-rate PredictionResistance workloads practical. Each byte is used once and zeroized immediately;
+	// the buffer is discarded after EntropyPrefetchMaxAge, a process fork, or a VM generation change.
+	//
+	// Must be between KeySize+16 and 1 MiB. Defaults to 0 (disabled).
+	EntropyPrefetchSize int
+
+	// EntropyPrefetchMaxAge is the maximum staleness of prefetched entropy. Entropy older than this is
+	// discarded unused. If zero, a default of 1 second is used. Only relevant if EntropyPrefetchSize is set.
+	EntropyPrefetchMaxAge time.Duration
+
+	// ExpvarName, if set, publishes the Reader's Stats in the expvar registry under this name, and hence on
+	// the /debug/vars endpoint when the expvar handler is served.
+	//
+	// Expvar names are process-global, and a name can be bound to only one open Reader at a time: NewReader
+	// returns an error wrapping ErrExpvarNameInUse if another open Reader, or anything else, has published
+	// it. The expvar registry cannot remove entries, so after Close, or once the Reader is garbage
+	// collected, the name stays published and reports null until a new Reader is created with it. The
+	// published value does not keep the Reader reachable. Defaults to "" (not published).
+	ExpvarName string
+
+	// prefetch is the shard's entropy prefetch buffer, installed per shard when EntropyPrefetchSize is set.
+	// It is runtime state and is not part of the static configuration.
+	prefetch *entropyPrefetcher
+
+	// generation is the Reader-wide generation ID watcher, installed by NewReader when a
+	// GenerationIDProvider is configured. It is runtime state and is not part of the static configuration.
+	generation *generationWatcher
+
+	// selfTest is the result of the Known Answer Tests run by NewReader, or nil if they were not run.
+	// It is reported by ComplianceReport, so reports do not rerun the tests.
+	selfTest *SelfTestEvent
+
 	// RekeyBackoff is the initial delay before retrying a failed rekey operation.
 	//
 	// Exponential backoff doubles the delay for each failure up to MaxRekeyBackoff.
@@ -98,7 +208,7 @@ type Config struct {
 	// MaxBytesPerKey is the maximum number of bytes generated per key before triggering automatic rekeying.
 	//
 	// Rekeying after a fixed output window enforces forward secrecy and mitigates key exposure risk.
-	// If set to zero, a default value of 1 GiB (1 << 30) is used.
+	// Must be nonzero when EnableKeyRotation is set. Defaults to 1 GiB (1 << 30).
 	MaxBytesPerKey uint64
 
 	// ReseedRequests is the maximum number of output requests (calls to Read) allowed before forcing a reseed.
@@ -113,7 +223,11 @@ type Config struct {
 	// If >0, fork detection is performed once every N output requests (advanced tuning; reduces overhead
 	// at the cost of a negligible window of risk).
 	//
-	// WARNING: Setting this above zero is NOT recommended for compliance-sensitive environments.
+	// On Linux 4.14 and later, each check is a single load from a MADV_WIPEONFORK sentinel page, so the
+	// default costs no system call; other Unix systems compare os.Getpid() against a cached PID.
+	//
+	// WARNING: Setting this above zero is NOT recommended for compliance-sensitive environments, and is
+	// rejected in Go's FIPS 140-3 mode (GODEBUG=fips140=on) and under ProfileFIPS140_3.
 	ForkDetectionInterval uint64
 
 	// KeySize specifies the AES key length to use for this DRBG instance.
@@ -144,16 +258,42 @@ type Config struct {
 
 	// Shards control the number of pools (shards) to use for parallelism.
 	//
-	// If zero, defaults to runtime.GOMAXPROCS(0).
+	// If zero, defaults to runtime.GOMAXPROCS(0); must not be negative.
 	// Increase this to improve throughput under high concurrency.
 	Shards int
 
+	// ShardStrategy selects how a Reader chooses a shard for each request.
+	//
+	// Defaults to ShardStrategyAffinity, which keeps each processor on a stable shard. ShardStrategyRandom
+	// (the historical behavior) and ShardStrategyRoundRobin are also available.
+	ShardStrategy ShardStrategy
+
 	// EnableKeyRotation controls whether instances automatically rotate their key after MaxBytesPerKey output.
 	//
 	// Automatic key rotation provides forward secrecy and aligns with cryptographic best practices.
 	// Defaults to true.
 	EnableKeyRotation bool
 
+	// RekeyMode selects how the key is rotated once MaxBytesPerKey bytes have been generated under it.
+	//
+	// Defaults to RekeyModeAsync, which never blocks a request but treats MaxBytesPerKey as a soft limit.
+	// RekeyModeSync and RekeyModeInline rotate the key within the request, blocking it, and bound the
+	// output generated under each key.
+	RekeyMode RekeyMode
+
+	// RekeyFailurePolicy selects what happens when key rotation exhausts MaxRekeyAttempts.
+	//
+	// Defaults to RekeyFailureContinue, which keeps generating under the exhausted key. RekeyFailureFailClosed
+	// refuses output with a *RekeyError until a new key is installed; RekeyFailureReseed falls back to a
+	// synchronous reseed.
+	RekeyFailurePolicy RekeyFailurePolicy
+
+	// RekeyFailureHandler, if set, is called with a *RekeyError every time key rotation exhausts its
+	// attempts, regardless of RekeyFailurePolicy, for example to raise an alert.
+	//
+	// It is called synchronously on the goroutine performing the request and must not block for long.
+	RekeyFailureHandler func(*RekeyError)
+
 	// PredictionResistance enables NIST SP 800-90A prediction resistance mode for this DRBG instance.
 	//
 	// When set to true, the DRBG will automatically reseed from fresh system entropy before every
@@ -183,6 +323,17 @@ type Config struct {
 	// Defaults to false.
 	UseZeroBuffer bool
 
+	// KeystreamCacheSize enables a per-instance keystream cache of this many bytes for small reads.
+	//
+	// When nonzero, reads of up to 64 bytes are served from a pre-generated keystream chunk instead of
+	// running AES for every request, and partial-block tails are no longer discarded. Served bytes are
+	// zeroized immediately, and the cache is wiped on reseed, rekey, and fork. Each refill is one generate
+	// request: it is health-tested and counted toward MaxBytesPerKey.
+	//
+	// Must be a multiple of 16 between 64 and MaxBytesPerRequest, and cannot be combined with
+	// PredictionResistance. Defaults to 0 (disabled).
+	KeystreamCacheSize int
+
 	// EnableSelfTests controls whether FIPS 140-2 Known Answer Tests (KAT) are run
 	// on first use of the DRBG to verify AES-CTR is functioning correctly.
 	//
@@ -203,6 +354,37 @@ type Config struct {
 	// blocks indicate catastrophic DRBG failure and return ErrHealthTestFailed.
 	// Required for FIPS 140-2/140-3 certification. Disabled by default.
 	ContinuousHealthTest bool
+
+	// Logger receives structured lifecycle events: reseeds (Debug), key rotations (Info), fork and VM
+	// generation change detection (Warn), and rotation, reseed, health test, and self-test failures (Error).
+	// Each event carries the shard and, where applicable, the cause and duration. No secret material is
+	// ever logged.
+	//
+	// When nil (default), nothing is logged.
+	Logger *slog.Logger
+
+	// Hooks holds optional callbacks for lifecycle events: reseeds, key rotations and their failures, fork
+	// and VM generation change detection, continuous health test failures, and self-test results. See Hooks.
+	//
+	// The zero value (default) installs no callbacks.
+	Hooks Hooks
+
+	// AuditLog, if set, records a tamper-evident, hash-chained audit trail of instantiation, reseed, key
+	// rotation, and zeroization events, with the entropy source of each seed. Records contain no secret
+	// state. See AuditLog.
+	//
+	// When nil (default), no audit trail is kept.
+	AuditLog *AuditLog
+
+	// Profile is the preset applied by WithProfile. Validate rejects settings that take the configuration
+	// outside the profile. See Profile.
+	//
+	// Defaults to ProfileNone, which imposes no constraints.
+	Profile Profile
+
+	// shard is the index of the shard this copy of the config belongs to, for log events. It is runtime
+	// state and is not part of the static configuration.
+	shard int
 }
 
 // Default configuration constants for AES-CTR-DRBG.
@@ -221,6 +403,9 @@ const (
 
 	// Default initial rekey backoff (100 ms)
 	defaultRekeyBackoff = 100 * time.Millisecond
+
+	// Default minimum time between VM generation ID checks (100 ms)
+	defaultGenerationIDCheckInterval = 100 * time.Millisecond
 )
 
 // DefaultConfig returns a Config struct populated with production-safe, NIST SP 800-90A §10.2.1-aligned defaults.
@@ -240,14 +425,31 @@ const (
 //   - MaxRekeyBackoff:    2 seconds (maximum exponential backoff between failed rekey attempts)
 //   - RekeyBackoff:       100 milliseconds (initial backoff for rekey attempts)
 //   - EnableKeyRotation:  false (key rotation is disabled by default—set to true for forward secrecy)
+//   - RekeyMode:          RekeyModeAsync (new keys prepared in the background)
+//   - RekeyFailurePolicy: RekeyFailureContinue (output continues if key rotation fails)
+//   - RekeyFailureHandler: nil (rotation failures are not reported)
 //   - EnableSelfTests:    false (FIPS 140-2 KAT self-tests disabled; enable via WithSelfTests for compliance)
 //   - EnableZeroization:  false (key zeroization disabled; enable via WithZeroization for FIPS 140-2 compliance)
 //   - Personalization:    nil (no domain separation unless set by the caller)
 //   - UseZeroBuffer:      false (random output generated directly into caller's buffer)
 //   - DefaultBufferSize:  0 (no preallocation of zero-filled buffers)
+//   - KeystreamCacheSize: 0 (small reads are generated per request)
 //   - Shards:             runtime.GOMAXPROCS(0) (number of internal DRBG pools matches available CPUs)
+//   - ShardStrategy:      ShardStrategyAffinity (per-processor shard affinity)
 //   - PredictionResistance: false (prediction resistance is disabled; enable only if required by policy)
 //   - ForkDetectionInterval: 0 (fork detection performed on every output request for maximum safety)
+//   - EntropySource:      nil (crypto/rand.Reader)
+//   - EntropyTimeout:     0 (no readiness wait)
+//   - Construction:       ConstructionUnspecified (no SP 800-90C construction enforced)
+//   - EntropyPrefetchSize: 0 (entropy read from the source for every seed)
+//   - EntropyPrefetchMaxAge: 0 (1 second when prefetch is enabled)
+//   - GenerationIDProvider: nil (no VM generation ID check)
+//   - GenerationIDCheckInterval: 100ms (generation ID checked at most every 100ms when a provider is set)
+//   - ExpvarName:         "" (Stats not published to expvar)
+//   - Logger:             nil (no lifecycle logging)
+//   - Hooks:              zero value (no lifecycle callbacks)
+//   - AuditLog:           nil (no audit trail)
+//   - Profile:            ProfileNone (no preset or profile constraints)
 //
 // NIST Reference:
 //   - See NIST SP 800-90A, §10.2.1 (CTR DRBG) for cryptographic construction details.
@@ -268,11 +470,124 @@ func DefaultConfig() Config {
 		UseZeroBuffer:         false,
 		DefaultBufferSize:     0,
 		Shards:                runtime.GOMAXPROCS(0),
+		ShardStrategy:         ShardStrategyAffinity,
 		PredictionResistance:  false,
 		ForkDetectionInterval: 0,
+
+		GenerationIDCheckInterval: defaultGenerationIDCheckInterval,
 	}
 }
 
+// Validate checks cfg and reports every problem found, rather than only the first, as errors joined with
+// errors.Join. Returns nil if cfg is valid.
+//
+// Each reported error wraps ErrInvalidConfig, ErrConstruction, ErrUncreditedSource, or ErrProfileViolation.
+// NewReader calls Validate before running self-tests or instantiating anything, so Validate can be used to
+// check a configuration ahead of time, for example at startup or in a deployment check.
+//
+// Example:
+//
+//	cfg := ctrdrbg.DefaultConfig()
+//	ctrdrbg.WithProfile(ctrdrbg.ProfileFIPS140_3)(&cfg)
+//	if err := cfg.Validate(); err != nil {
+//	    log.Fatalf("invalid DRBG configuration: %v", err)
+//	}
+func (cfg Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalidConfig}, args...)...))
+	}
+
+	// Only 16, 24, or 32 bytes (AES-128, AES-192, AES-256) are supported.
+	switch cfg.KeySize {
+	case KeySize128, KeySize192, KeySize256:
+	default:
+		invalid("key size %d bytes: must be 16, 24, or 32", cfg.KeySize)
+	}
+
+	if cfg.MaxInitRetries < 1 {
+		invalid("MaxInitRetries %d: must be at least 1", cfg.MaxInitRetries)
+	}
+	if cfg.MaxRekeyAttempts < 0 {
+		invalid("MaxRekeyAttempts %d: must not be negative", cfg.MaxRekeyAttempts)
+	}
+	if cfg.Shards < 0 {
+		invalid("Shards %d: must not be negative", cfg.Shards)
+	}
+	if cfg.DefaultBufferSize < 0 {
+		invalid("DefaultBufferSize %d: must not be negative", cfg.DefaultBufferSize)
+	}
+
+	// A zero limit would rotate the key on every request.
+	if cfg.EnableKeyRotation && cfg.MaxBytesPerKey == 0 {
+		invalid("MaxBytesPerKey must be nonzero when key rotation is enabled")
+	}
+
+	// NIST SP 800-90A §10.2.1, Table 3: reseed_interval is at most 2^48 requests.
+	if cfg.ReseedRequests > maxReseedInterval {
+		invalid("ReseedRequests %d: exceeds the maximum of 2^48", cfg.ReseedRequests)
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"ReseedInterval", cfg.ReseedInterval},
+		{"RekeyBackoff", cfg.RekeyBackoff},
+		{"MaxRekeyBackoff", cfg.MaxRekeyBackoff},
+		{"EntropyTimeout", cfg.EntropyTimeout},
+		{"GenerationIDCheckInterval", cfg.GenerationIDCheckInterval},
+	} {
+		if d.value < 0 {
+			invalid("%s %s: must not be negative", d.name, d.value)
+		}
+	}
+
+	// A forked child could repeat its parent's output until the next check.
+	if cfg.ForkDetectionInterval != 0 && fips140.Enabled() {
+		invalid("ForkDetectionInterval %d: must be 0 in FIPS 140-3 mode", cfg.ForkDetectionInterval)
+	}
+
+	switch cfg.ShardStrategy {
+	case ShardStrategyAffinity, ShardStrategyRandom, ShardStrategyRoundRobin:
+	default:
+		invalid("shard strategy %s", cfg.ShardStrategy)
+	}
+
+	switch cfg.RekeyMode {
+	case RekeyModeAsync, RekeyModeSync, RekeyModeInline:
+	default:
+		invalid("rekey mode %s", cfg.RekeyMode)
+	}
+
+	switch cfg.RekeyFailurePolicy {
+	case RekeyFailureContinue, RekeyFailureFailClosed, RekeyFailureReseed:
+	default:
+		invalid("rekey failure policy %s", cfg.RekeyFailurePolicy)
+	}
+
+	if err := validateKeystreamCache(&cfg); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateEntropyPrefetch(&cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Enforce the requirements of the selected SP 800-90C construction, if any.
+	if err := validateConstruction(&cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Sources that are not credited with full entropy (e.g., a randomness beacon) may only be
+	// used as additional input, never as the primary entropy source.
+	if !isCredited(cfg.entropySource()) {
+		errs = append(errs, fmt.Errorf("%w: %s (use WithAdditionalInputSource)", ErrUncreditedSource, cfg.entropySource().Name()))
+	}
+
+	errs = append(errs, validateProfile(&cfg)...)
+	return errors.Join(errs...)
+}
+
 // Option defines a functional option for customizing a Config.
 //
 // Use Option values with NewReader or other constructors that accept variadic options.
@@ -308,7 +623,8 @@ func WithMaxInitRetries(n int) Option { return func(cfg *Config) { cfg.MaxInitRe
 // WithMaxRekeyAttempts returns an Option that sets the maximum number of attempts allowed for
 // asynchronous key rotation (rekey) in the DRBG.
 //
-// If all rekey attempts fail, the DRBG continues using the previous state. Exponential backoff is applied
+// If all rekey attempts fail, RekeyFailurePolicy applies (by default, the DRBG continues using the previous
+// state). Exponential backoff is applied
 // between attempts (see WithMaxRekeyBackoff and WithRekeyBackoff).
 func WithMaxRekeyAttempts(n int) Option { return func(cfg *Config) { cfg.MaxRekeyAttempts = n } }
 
@@ -339,6 +655,26 @@ func WithEnableKeyRotation(enable bool) Option {
 	return func(cfg *Config) { cfg.EnableKeyRotation = enable }
 }
 
+// WithRekeyMode returns an Option that selects how the key is rotated once MaxBytesPerKey is reached.
+//
+// Use RekeyModeSync or RekeyModeInline when the number of bytes generated under a key must be strictly
+// bounded; the request that triggers the rotation then waits for it. See RekeyMode.
+func WithRekeyMode(m RekeyMode) Option {
+	return func(cfg *Config) { cfg.RekeyMode = m }
+}
+
+// WithRekeyFailurePolicy returns an Option that selects what happens when key rotation exhausts
+// MaxRekeyAttempts. See RekeyFailurePolicy.
+func WithRekeyFailurePolicy(p RekeyFailurePolicy) Option {
+	return func(cfg *Config) { cfg.RekeyFailurePolicy = p }
+}
+
+// WithRekeyFailureHandler returns an Option that registers fn to receive a *RekeyError every time key
+// rotation exhausts its attempts. fn is called synchronously and must not block for long.
+func WithRekeyFailureHandler(fn func(*RekeyError)) Option {
+	return func(cfg *Config) { cfg.RekeyFailureHandler = fn }
+}
+
 // WithPersonalization returns an Option that sets a per-instance personalization string
 // to be XOR-ed into the DRBG's seed for domain separation.
 //
@@ -382,6 +718,22 @@ func WithShards(n int) Option {
 	}
 }
 
+// WithKeystreamCache returns an Option that serves reads of up to 64 bytes from a per-instance keystream
+// cache of size bytes, amortizing AES work across many small requests (e.g., UUIDs and tokens).
+//
+// size must be a multiple of 16 between 64 and MaxBytesPerRequest; zero disables the cache.
+// See Config.KeystreamCacheSize.
+func WithKeystreamCache(size int) Option {
+	return func(cfg *Config) { cfg.KeystreamCacheSize = size }
+}
+
+// WithShardStrategy returns an Option that sets how a Reader chooses the shard for each request.
+//
+// See ShardStrategy for the available strategies. The default is ShardStrategyAffinity.
+func WithShardStrategy(s ShardStrategy) Option {
+	return func(cfg *Config) { cfg.ShardStrategy = s }
+}
+
 // WithPredictionResistance returns an Option that enables or disables NIST SP 800-90A prediction resistance mode.
 //
 // When enabled (true), the DRBG reseeds from fresh system entropy before every output generation,
@@ -448,3 +800,99 @@ func WithContinuousHealthTest(enable bool) Option {
 		c.ContinuousHealthTest = enable
 	}
 }
+
+// WithEntropySource returns an Option that sets the EntropySource used to instantiate and reseed DRBG instances.
+//
+// Passing nil restores the default crypto/rand-backed source.
+func WithEntropySource(src EntropySource) Option {
+	return func(cfg *Config) { cfg.EntropySource = src }
+}
+
+// WithEntropyTimeout returns an Option that bounds how long NewReader waits for the entropy source
+// to become ready.
+//
+// If the source (for example, the Linux kernel CRNG at early boot) is not ready within d, NewReader
+// returns an *EntropyNotReadyError. Zero disables the wait.
+func WithEntropyTimeout(d time.Duration) Option {
+	return func(cfg *Config) { cfg.EntropyTimeout = d }
+}
+
+// WithAdditionalInputSource returns an Option that mixes output from src into every instantiation and reseed
+// as additional input.
+//
+// Use this for sources that must not be credited as entropy, such as a public randomness beacon
+// (see NewBeaconSource). Read failures from src are ignored.
+func WithAdditionalInputSource(src EntropySource) Option {
+	return func(cfg *Config) { cfg.AdditionalInputSource = src }
+}
+
+// WithConstruction returns an Option that selects the NIST SP 800-90C RBG construction to enforce.
+//
+// See Construction for the requirements of each construction.
+func WithConstruction(c Construction) Option {
+	return func(cfg *Config) { cfg.Construction = c }
+}
+
+// WithGenerationIDProvider returns an Option that reseeds every DRBG instance when the VM generation ID
+// reported by p changes, protecting against output replay after a VM snapshot restore or clone.
+//
+// See NewSysfsGenerationIDProvider for a file-backed provider and FakeGenerationIDProvider for tests.
+func WithGenerationIDProvider(p GenerationIDProvider) Option {
+	return func(cfg *Config) { cfg.GenerationIDProvider = p }
+}
+
+// WithGenerationIDCheckInterval returns an Option that sets the minimum time between VM generation ID checks.
+//
+// The default is 100ms; zero checks on every output request.
+func WithGenerationIDCheckInterval(d time.Duration) Option {
+	return func(cfg *Config) { cfg.GenerationIDCheckInterval = d }
+}
+
+// WithEntropyPrefetch returns an Option that enables a per-shard entropy prefetch buffer of size bytes,
+// whose contents are discarded once older than maxAge (zero selects the 1 second default).
+//
+// Prefetching batches entropy source reads, which makes PredictionResistance practical at high request
+// rates. See Config.EntropyPrefetchSize.
+func WithEntropyPrefetch(size int, maxAge time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.EntropyPrefetchSize = size
+		cfg.EntropyPrefetchMaxAge = maxAge
+	}
+}
+
+// WithExpvar returns an Option that publishes the Reader's Stats in the expvar registry under name.
+//
+// See Config.ExpvarName. For Prometheus scraping, see MetricsHandler.
+func WithExpvar(name string) Option {
+	return func(cfg *Config) { cfg.ExpvarName = name }
+}
+
+// WithLogger returns an Option that emits structured lifecycle events (reseeds, key rotations, fork and
+// VM generation change detection, health test and self-test failures) to l.
+//
+// See Config.Logger.
+func WithLogger(l *slog.Logger) Option {
+	return func(cfg *Config) { cfg.Logger = l }
+}
+
+// WithHooks returns an Option that installs callbacks for DRBG lifecycle events, for example to page on a
+// health test failure or to invalidate caches after a fork.
+//
+// See Hooks for when and where each callback runs.
+func WithHooks(h Hooks) Option {
+	return func(cfg *Config) { cfg.Hooks = h }
+}
+
+// WithAuditLog returns an Option that records instantiation, reseed, key rotation, and zeroization events
+// to a.
+//
+// Example:
+//
+//	sink, err := ctrdrbg.OpenAuditFile("/var/log/drbg-audit.jsonl")
+//	if err != nil {
+//	    // handle error
+//	}
+//	r, err := ctrdrbg.NewReader(ctrdrbg.WithAuditLog(ctrdrbg.NewAuditLog(sink)))
+func WithAuditLog(a *AuditLog) Option {
+	return func(cfg *Config) { cfg.AuditLog = a }
+}
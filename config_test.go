@@ -6,6 +6,8 @@
 package ctrdrbg
 
 import (
+	"log/slog"
+	"runtime"
 	"testing"
 	"time"
 
@@ -281,3 +283,276 @@ func TestConfig_WithContinuousHealthTest(t *testing.T) {
 	WithContinuousHealthTest(false)(&cfg)
 	is.False(cfg.ContinuousHealthTest, "WithContinuousHealthTest(false) should set ContinuousHealthTest to false")
 }
+
+// TestConfig_WithEntropySource verifies that WithEntropySource sets the EntropySource field.
+func TestConfig_WithEntropySource(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.EntropySource, "EntropySource should default to nil")
+
+	src := &countingSource{}
+	WithEntropySource(src)(&cfg)
+	is.Equal(src, cfg.EntropySource, "WithEntropySource should set EntropySource")
+}
+
+// TestConfig_WithEntropyTimeout verifies that WithEntropyTimeout sets the EntropyTimeout field.
+func TestConfig_WithEntropyTimeout(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Zero(cfg.EntropyTimeout, "EntropyTimeout should default to zero")
+
+	WithEntropyTimeout(3 * time.Second)(&cfg)
+	is.Equal(3*time.Second, cfg.EntropyTimeout, "WithEntropyTimeout should set EntropyTimeout")
+}
+
+// TestConfig_WithAdditionalInputSource verifies that WithAdditionalInputSource sets the AdditionalInputSource field.
+func TestConfig_WithAdditionalInputSource(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.AdditionalInputSource, "AdditionalInputSource should default to nil")
+
+	src := &countingSource{}
+	WithAdditionalInputSource(src)(&cfg)
+	is.Equal(src, cfg.AdditionalInputSource, "WithAdditionalInputSource should set AdditionalInputSource")
+}
+
+// TestConfig_WithConstruction verifies that WithConstruction sets the Construction field.
+func TestConfig_WithConstruction(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(ConstructionUnspecified, cfg.Construction, "Construction should default to unspecified")
+
+	WithConstruction(ConstructionRBG2NP)(&cfg)
+	is.Equal(ConstructionRBG2NP, cfg.Construction, "WithConstruction should set Construction")
+}
+
+// TestConfig_WithGenerationIDProvider verifies that WithGenerationIDProvider sets the GenerationIDProvider field.
+func TestConfig_WithGenerationIDProvider(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.GenerationIDProvider, "GenerationIDProvider should default to nil")
+
+	p := NewFakeGenerationIDProvider()
+	WithGenerationIDProvider(p)(&cfg)
+	is.Equal(p, cfg.GenerationIDProvider, "WithGenerationIDProvider should set GenerationIDProvider")
+}
+
+// TestConfig_WithGenerationIDCheckInterval verifies that WithGenerationIDCheckInterval sets the GenerationIDCheckInterval field.
+func TestConfig_WithGenerationIDCheckInterval(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(defaultGenerationIDCheckInterval, cfg.GenerationIDCheckInterval, "GenerationIDCheckInterval should default to 100ms")
+
+	WithGenerationIDCheckInterval(time.Second)(&cfg)
+	is.Equal(time.Second, cfg.GenerationIDCheckInterval, "WithGenerationIDCheckInterval should set GenerationIDCheckInterval")
+}
+
+// TestConfig_WithShardStrategy verifies that WithShardStrategy sets the ShardStrategy field.
+func TestConfig_WithShardStrategy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(ShardStrategyAffinity, cfg.ShardStrategy, "ShardStrategy should default to affinity")
+
+	WithShardStrategy(ShardStrategyRoundRobin)(&cfg)
+	is.Equal(ShardStrategyRoundRobin, cfg.ShardStrategy, "WithShardStrategy should set ShardStrategy")
+}
+
+// TestConfig_WithKeystreamCache verifies that WithKeystreamCache sets the KeystreamCacheSize field.
+func TestConfig_WithKeystreamCache(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Zero(cfg.KeystreamCacheSize, "KeystreamCacheSize should default to zero")
+
+	WithKeystreamCache(4096)(&cfg)
+	is.Equal(4096, cfg.KeystreamCacheSize, "WithKeystreamCache should set KeystreamCacheSize")
+}
+
+// TestConfig_WithEntropyPrefetch verifies that WithEntropyPrefetch sets the EntropyPrefetchSize and
+// EntropyPrefetchMaxAge fields.
+func TestConfig_WithEntropyPrefetch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Zero(cfg.EntropyPrefetchSize, "EntropyPrefetchSize should default to zero")
+	is.Zero(cfg.EntropyPrefetchMaxAge, "EntropyPrefetchMaxAge should default to zero")
+
+	WithEntropyPrefetch(4096, time.Second)(&cfg)
+	is.Equal(4096, cfg.EntropyPrefetchSize, "WithEntropyPrefetch should set EntropyPrefetchSize")
+	is.Equal(time.Second, cfg.EntropyPrefetchMaxAge, "WithEntropyPrefetch should set EntropyPrefetchMaxAge")
+}
+
+// TestConfig_WithRekeyMode verifies that WithRekeyMode sets the RekeyMode field.
+func TestConfig_WithRekeyMode(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(RekeyModeAsync, cfg.RekeyMode, "RekeyMode should default to async")
+
+	WithRekeyMode(RekeyModeInline)(&cfg)
+	is.Equal(RekeyModeInline, cfg.RekeyMode, "WithRekeyMode should set RekeyMode")
+}
+
+// TestConfig_WithRekeyFailurePolicy verifies that WithRekeyFailurePolicy sets the RekeyFailurePolicy field.
+func TestConfig_WithRekeyFailurePolicy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(RekeyFailureContinue, cfg.RekeyFailurePolicy, "RekeyFailurePolicy should default to continue")
+
+	WithRekeyFailurePolicy(RekeyFailureFailClosed)(&cfg)
+	is.Equal(RekeyFailureFailClosed, cfg.RekeyFailurePolicy, "WithRekeyFailurePolicy should set RekeyFailurePolicy")
+}
+
+// TestConfig_WithRekeyFailureHandler verifies that WithRekeyFailureHandler sets the RekeyFailureHandler field.
+func TestConfig_WithRekeyFailureHandler(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.RekeyFailureHandler, "RekeyFailureHandler should default to nil")
+
+	called := false
+	WithRekeyFailureHandler(func(*RekeyError) { called = true })(&cfg)
+	is.NotNil(cfg.RekeyFailureHandler)
+	cfg.RekeyFailureHandler(&RekeyError{})
+	is.True(called, "WithRekeyFailureHandler should set RekeyFailureHandler")
+}
+
+// TestConfig_WithExpvar verifies that WithExpvar sets the ExpvarName field.
+func TestConfig_WithExpvar(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Empty(cfg.ExpvarName, "ExpvarName should default to empty")
+
+	WithExpvar("drbg")(&cfg)
+	is.Equal("drbg", cfg.ExpvarName, "WithExpvar should set ExpvarName")
+}
+
+// TestConfig_WithLogger verifies that WithLogger sets the Logger field.
+func TestConfig_WithLogger(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.Logger, "Logger should default to nil")
+
+	l := slog.New(slog.DiscardHandler)
+	WithLogger(l)(&cfg)
+	is.Same(l, cfg.Logger, "WithLogger should set Logger")
+}
+
+// TestConfig_WithHooks verifies that WithHooks sets the Hooks field.
+func TestConfig_WithHooks(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.False(cfg.Hooks.set(), "Hooks should default to no callbacks")
+
+	WithHooks(Hooks{OnFork: func(ForkEvent) {}})(&cfg)
+	is.NotNil(cfg.Hooks.OnFork, "WithHooks should set Hooks")
+	is.True(cfg.Hooks.set())
+}
+
+// TestConfig_WithAuditLog verifies that WithAuditLog sets the AuditLog field.
+func TestConfig_WithAuditLog(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Nil(cfg.AuditLog, "AuditLog should default to nil")
+
+	a := NewAuditLog(NewMemoryAuditSink())
+	WithAuditLog(a)(&cfg)
+	is.Same(a, cfg.AuditLog, "WithAuditLog should set AuditLog")
+}
+
+// TestConfig_WithProfile verifies that WithProfile records the profile and applies its presets.
+func TestConfig_WithProfile(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	is.Equal(ProfileNone, cfg.Profile, "Profile should default to ProfileNone")
+
+	WithProfile(ProfileFIPS140_3)(&cfg)
+	is.Equal(ProfileFIPS140_3, cfg.Profile)
+	is.True(cfg.EnableSelfTests)
+	is.True(cfg.EnableZeroization)
+	is.True(cfg.ContinuousHealthTest)
+	is.Equal(uint64(fips140ReseedRequests), cfg.ReseedRequests)
+	is.True(cfg.EnableKeyRotation)
+	is.Equal(RekeyFailureFailClosed, cfg.RekeyFailurePolicy)
+
+	cfg = DefaultConfig()
+	WithProfile(ProfilePerformance)(&cfg)
+	is.Equal(ProfilePerformance, cfg.Profile)
+	is.Equal(performanceKeystreamCacheSize, cfg.KeystreamCacheSize)
+	is.Equal(RekeyModeAsync, cfg.RekeyMode)
+}
+
+// TestConfig_Validate verifies that Validate accepts the default configuration and reports every invalid
+// setting, not just the first.
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.NoError(DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	cfg.Shards = -1
+	cfg.EnableKeyRotation = true
+	cfg.MaxBytesPerKey = 0
+	cfg.KeySize = 20
+	cfg.ReseedRequests = maxReseedInterval + 1
+	cfg.RekeyBackoff = -time.Second
+	cfg.ShardStrategy = ShardStrategy(9)
+
+	err := cfg.Validate()
+	is.ErrorIs(err, ErrInvalidConfig)
+	var joined interface{ Unwrap() []error }
+	if is.ErrorAs(err, &joined) {
+		is.Len(joined.Unwrap(), 6)
+	}
+	for _, want := range []string{"Shards -1", "MaxBytesPerKey", "key size 20", "ReseedRequests", "RekeyBackoff",
+		"shard strategy"} {
+		is.Contains(err.Error(), want)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Construction = ConstructionRBG3XOR
+	cfg.KeySize = KeySize128
+	cfg.KeystreamCacheSize = 10
+	err = cfg.Validate()
+	is.ErrorIs(err, ErrConstruction)
+	is.ErrorIs(err, ErrInvalidConfig)
+
+	// Shards set directly on Config: zero takes the default, negative is rejected.
+	r, err := NewReader(func(cfg *Config) { cfg.Shards = 0 })
+	is.NoError(err)
+	is.Equal(runtime.GOMAXPROCS(0), r.Config().Shards)
+	is.NoError(r.Close())
+	_, err = NewReader(func(cfg *Config) { cfg.Shards = -1 })
+	is.ErrorIs(err, ErrInvalidConfig)
+}
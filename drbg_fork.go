@@ -8,8 +8,7 @@
 package ctrdrbg
 
 import (
-	"os"
-	"sync/atomic"
+	"sync"
 )
 
 // reseedIfForked performs fork detection and conditional reseeding according to the configured interval.
@@ -19,10 +18,14 @@ import (
 // If ForkDetectionInterval is set to a nonzero value N, the fork detection check is only performed every N output requests.
 //
 // Semantics and Behavior:
-//   - Increments the output request counter atomically.
+//   - Increments the fork check counter (ForkDetectionInterval > 0 only).
 //   - If ForkDetectionInterval is zero, always checks for fork (fully compliant, safest).
 //   - If ForkDetectionInterval is N>0, checks only every Nth output request (performance-tuned, non-compliant).
-//   - If a fork is detected (current PID != cached PID), reseeds the DRBG instance and updates the cached PID.
+//   - If a fork is detected (current fork epoch != cached fork epoch), reseeds the DRBG instance and updates
+//     the cached epoch.
+//
+// On Linux 4.14 and later the check is a single load from a MADV_WIPEONFORK sentinel page (see processEpoch);
+// elsewhere it compares os.Getpid() against the PID cached at the last check.
 //
 // Security Rationale:
 //   - Forking a process duplicates DRBG state. If not reseeded, parent and child may produce identical output, violating forward secrecy.
@@ -32,24 +35,26 @@ import (
 //   - Call at the beginning of every output-generating operation (e.g., Read, ReadWithAdditionalInput).
 func (d *drbg) reseedIfForked() {
 	interval := d.config.ForkDetectionInterval
-	if interval == 0 {
-		// Default: always check for fork
-		current := os.Getpid()
-		if current != d.pid {
-			_ = d.Reseed(nil) // Best-effort reseed
-			d.pid = current
+	if interval != 0 {
+		// Only check every Nth request
+		d.forkChecks++
+		if d.forkChecks%interval != 0 {
+			return // Not time to check yet
 		}
-		return
 	}
 
-	// Only check every Nth request
-	n := atomic.AddUint64(&d.requests, 1)
-	if n%interval != 0 {
-		return // Not time to check yet
-	}
-	current := os.Getpid()
-	if current != d.pid {
-		_ = d.Reseed(nil) // Best-effort reseed
-		d.pid = current
+	if current := processEpoch(); current != d.forkEpoch {
+		d.logDetected(ReseedCauseFork)
+		err := d.reseed(ReseedCauseFork, nil) // Best-effort reseed (re-instantiation for RBG1)
+		d.forkEpoch = current
+
+		// A rekey goroutine started in the parent does not exist in the child; abandon its result so key
+		// rotation can start again, and replace the WaitGroup that still counts it, which would otherwise
+		// block uninstantiate forever.
+		if d.rekeying {
+			d.rekeying = false
+			d.rekeyWG = sync.WaitGroup{}
+		}
+		d.forkEvent(ReseedCauseFork, err)
 	}
 }
@@ -0,0 +1,79 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build linux
+
+package ctrdrbg
+
+import (
+	"os"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// madvWipeOnFork is MADV_WIPEONFORK (Linux 4.14+), which the frozen syscall package does not define.
+const madvWipeOnFork = 18
+
+// Sentinel values. A child process observes sentinelWiped, since the kernel zero-fills the page on fork.
+const (
+	sentinelWiped    = 0
+	sentinelArmed    = 1
+	sentinelUpdating = 2
+)
+
+var (
+	// forkSentinel points into a private anonymous page mapped with MADV_WIPEONFORK, or is nil if the
+	// kernel does not support it.
+	forkSentinel = mapForkSentinel()
+
+	// observedForks is incremented each time the sentinel is found wiped, i.e., once per fork observed.
+	observedForks atomic.Uint64
+)
+
+// mapForkSentinel maps one page, marks it MADV_WIPEONFORK, and arms it.
+//
+// Returns nil if mapping fails or the kernel rejects the advice (EINVAL before Linux 4.14), in which case
+// processEpoch falls back to os.Getpid.
+func mapForkSentinel() *uint32 {
+	page, err := syscall.Mmap(-1, 0, os.Getpagesize(), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE|syscall.MAP_ANON)
+	if err != nil {
+		return nil
+	}
+	if err = syscall.Madvise(page, madvWipeOnFork); err != nil {
+		_ = syscall.Munmap(page)
+		return nil
+	}
+	s := (*uint32)(unsafe.Pointer(&page[0]))
+	atomic.StoreUint32(s, sentinelArmed)
+	return s
+}
+
+// processEpoch returns a value that differs in a child process from the value observed in its parent.
+//
+// With a wipe-on-fork sentinel, the common case is a single atomic load with no system call. The first
+// caller to find the sentinel wiped advances observedForks and re-arms it; concurrent callers wait for the
+// new epoch so none of them misses the fork. Without a sentinel, the process ID is returned.
+func processEpoch() uint64 {
+	s := forkSentinel
+	if s == nil {
+		return uint64(os.Getpid())
+	}
+	for {
+		switch atomic.LoadUint32(s) {
+		case sentinelArmed:
+			return observedForks.Load()
+		case sentinelWiped:
+			if atomic.CompareAndSwapUint32(s, sentinelWiped, sentinelUpdating) {
+				e := observedForks.Add(1)
+				atomic.StoreUint32(s, sentinelArmed)
+				return e
+			}
+		default:
+			runtime.Gosched()
+		}
+	}
+}
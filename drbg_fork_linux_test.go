@@ -0,0 +1,165 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+// The race detector runtime cannot run in a child created by a raw fork.
+//go:build linux && !race
+
+package ctrdrbg
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_ProcessEpoch_WipeOnFork forks a helper process and verifies that the child observes a new fork epoch
+// through the wiped sentinel page, so every DRBG instance it inherited reseeds before producing output.
+//
+// The child runs only atomic loads and raw system calls: the Go runtime does not support continuing after
+// a raw fork, so the reseed itself (which allocates) is covered by Test_DRBG_ReseedIfForked.
+func Test_ProcessEpoch_WipeOnFork(t *testing.T) {
+	is := assert.New(t)
+
+	if forkSentinel == nil {
+		t.Skip("MADV_WIPEONFORK not supported by this kernel")
+	}
+
+	cfg := DefaultConfig()
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+	parentEpoch := d.forkEpoch
+
+	r, w, err := os.Pipe()
+	is.NoError(err)
+	defer r.Close()
+	wfd := w.Fd()
+
+	pid, _, errno := syscall.RawSyscall6(syscall.SYS_CLONE, uintptr(syscall.SIGCHLD), 0, 0, 0, 0, 0)
+	if errno != 0 {
+		_ = w.Close()
+		t.Fatalf("fork: %v", errno)
+	}
+	if pid == 0 {
+		// Child: report whether the sentinel was wiped and the inherited instance would reseed.
+		var res [2]byte
+		if *forkSentinel == sentinelWiped {
+			res[0] = 1
+		}
+		if processEpoch() != d.forkEpoch {
+			res[1] = 1
+		}
+		syscall.RawSyscall(syscall.SYS_WRITE, wfd, uintptr(unsafe.Pointer(&res[0])), uintptr(len(res)))
+		syscall.RawSyscall(syscall.SYS_EXIT_GROUP, 0, 0, 0)
+	}
+	_ = w.Close()
+
+	var ws syscall.WaitStatus
+	_, err = syscall.Wait4(int(pid), &ws, 0, nil)
+	is.NoError(err)
+	is.True(ws.Exited())
+
+	res := make([]byte, 2)
+	n, err := r.Read(res)
+	is.NoError(err)
+	is.Equal(2, n)
+	is.Equal(byte(1), res[0], "child should observe a wiped sentinel page")
+	is.Equal(byte(1), res[1], "child should observe a new fork epoch and reseed")
+
+	is.Equal(uint32(sentinelArmed), *forkSentinel, "parent sentinel should be unaffected by the fork")
+	is.Equal(parentEpoch, processEpoch(), "parent epoch should be unaffected by the fork")
+}
+
+// forkChildEnv marks the re-executed test binary in which Test_DRBG_ForkReseed forks.
+const forkChildEnv = "CTRDRBG_FORK_CHILD"
+
+// Test_DRBG_ForkReseed verifies that an instance inherited by a forked child reseeds before producing
+// output, so that the child's output differs from what the parent produces from the same state.
+//
+// The fork runs in a re-executed copy of the test binary with GOMAXPROCS=1 and the garbage collector
+// disabled, so that the child can run the reseed, which allocates and reads the entropy source, on the
+// single thread it inherits.
+func Test_DRBG_ForkReseed(t *testing.T) {
+	if os.Getenv(forkChildEnv) != "" {
+		forkReseedChild(t)
+		return
+	}
+	is := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, os.Args[0], "-test.run=^Test_DRBG_ForkReseed$", "-test.count=1", "-test.v")
+	cmd.Env = append(os.Environ(), forkChildEnv+"=1", "GOMAXPROCS=1", "GOGC=off")
+	out, err := cmd.CombinedOutput()
+	is.NoError(err, "%s", out)
+	is.Contains(string(out), "--- PASS: Test_DRBG_ForkReseed", "%s", out)
+}
+
+// forkReseedChild is the body of Test_DRBG_ForkReseed in the re-executed test binary. It forks, reads from
+// an inherited instance in the child, and compares the result with the parent's next output.
+func forkReseedChild(t *testing.T) {
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+	old := d.state
+
+	r, w, err := os.Pipe()
+	is.NoError(err)
+	defer r.Close()
+	wfd := w.Fd()
+
+	// The child reports whether its read succeeded from a new state, followed by its output.
+	var res [1 + 32]byte
+	pid, _, errno := syscall.RawSyscall6(syscall.SYS_CLONE, uintptr(syscall.SIGCHLD), 0, 0, 0, 0, 0)
+	if errno != 0 {
+		_ = w.Close()
+		t.Fatalf("fork: %v", errno)
+	}
+	if pid == 0 {
+		if _, err := d.Read(res[1:]); err == nil && d.state != old {
+			res[0] = 1
+		}
+		syscall.RawSyscall(syscall.SYS_WRITE, wfd, uintptr(unsafe.Pointer(&res[0])), uintptr(len(res)))
+		syscall.RawSyscall(syscall.SYS_EXIT_GROUP, 0, 0, 0)
+	}
+	_ = w.Close()
+
+	var ws syscall.WaitStatus
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		wpid, err := syscall.Wait4(int(pid), &ws, syscall.WNOHANG, nil)
+		if err != nil {
+			t.Fatalf("wait4: %v", err)
+		}
+		if wpid == int(pid) {
+			break
+		}
+		if time.Now().After(deadline) {
+			_ = syscall.Kill(int(pid), syscall.SIGKILL)
+			_, _ = syscall.Wait4(int(pid), &ws, 0, nil)
+			t.Fatal("forked child did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	is.True(ws.Exited())
+
+	child := make([]byte, len(res))
+	_, err = io.ReadFull(r, child)
+	is.NoError(err)
+	is.Equal(byte(1), child[0], "child should reseed before producing output")
+
+	parent := make([]byte, len(res)-1)
+	_, err = d.Read(parent)
+	is.NoError(err)
+	is.Same(old, d.state, "parent should not reseed")
+	is.NotEqual(parent, child[1:], "child output must differ from the parent's output from the same state")
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !linux && !windows
+
+package ctrdrbg
+
+import (
+	"os"
+)
+
+// processEpoch returns the process ID, which differs in a child process from that of its parent.
+//
+// MADV_WIPEONFORK is Linux-specific, so other Unix systems detect forks with os.Getpid.
+func processEpoch() uint64 {
+	return uint64(os.Getpid())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !windows
+
+package ctrdrbg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_DRBG_ReseedIfForked verifies that an instance reseeds exactly once when the fork epoch changes.
+func Test_DRBG_ReseedIfForked(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+	is.Equal(processEpoch(), d.forkEpoch)
+
+	buf := make([]byte, 32)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	old := d.state
+
+	// Simulate running in a child process: the cached epoch is the parent's.
+	d.forkEpoch--
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.NotSame(old, d.state, "fork should reseed the instance")
+	is.Equal(processEpoch(), d.forkEpoch)
+
+	old = d.state
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Same(old, d.state, "unchanged epoch should not reseed")
+}
+
+// Test_DRBG_ReseedIfForked_Interval verifies that a nonzero ForkDetectionInterval defers the check.
+func Test_DRBG_ReseedIfForked_Interval(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.ForkDetectionInterval = 4
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	old := d.state
+	d.forkEpoch--
+	buf := make([]byte, 32)
+	for i := 0; i < 3; i++ {
+		_, err = d.Read(buf)
+		is.NoError(err)
+		is.Same(old, d.state, "fork should not be checked before the interval elapses")
+	}
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.NotSame(old, d.state, "fork should be detected on the Nth request")
+}
+
+// Test_DRBG_ReseedIfForked_PendingRekey verifies that a child whose parent had a background rekey in flight
+// abandons it, so that key rotation can restart and uninstantiate does not wait for a goroutine that does
+// not exist in the child.
+func Test_DRBG_ReseedIfForked_PendingRekey(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.EnableKeyRotation = true
+	cfg.RekeyMode = RekeyModeAsync
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	// Simulate the state inherited from the parent: a rekey counted in rekeyWG whose goroutine was not copied.
+	d.rekeying = true
+	d.rekeyResult.Store(rekeyPending)
+	d.rekeyWG.Add(1)
+	d.forkEpoch--
+
+	_, err = d.Read(make([]byte, 32))
+	is.NoError(err)
+	is.False(d.rekeying, "the parent's rekey should be abandoned")
+
+	done := make(chan struct{})
+	go func() {
+		d.uninstantiate()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("uninstantiate blocked on the parent's rekey")
+	}
+}
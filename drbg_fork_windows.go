@@ -30,3 +30,8 @@ package ctrdrbg
 func (d *drbg) reseedIfForked() {
 	// No-op: Windows does not implement fork(), so fork detection is unnecessary.
 }
+
+// processEpoch returns a constant: Windows does not implement fork(), so the epoch never changes.
+func processEpoch() uint64 {
+	return 0
+}
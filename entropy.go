@@ -0,0 +1,194 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	// ErrEntropyNotReady is the sentinel matched (via errors.Is) by *EntropyNotReadyError.
+	ErrEntropyNotReady = errors.New("ctrdrbg: entropy source not ready")
+)
+
+// EntropySource supplies the entropy input used to instantiate and reseed DRBG instances.
+//
+// Implementations must fill the entire buffer passed to Read with entropy, or return an error.
+// Sources are shared by every DRBG instance of a Reader and must be safe for concurrent use.
+//
+// In Go's FIPS-140 mode (GODEBUG=fips140=on), crypto/rand.Reader is itself a DRBG, so seeding
+// from it is a DRBG-from-DRBG construction rather than seeding from an entropy source. Use
+// NewGetrandomSource on Linux to seed directly from the kernel via getrandom(2).
+type EntropySource interface {
+	io.Reader
+
+	// Name returns a short, stable identifier for the source (e.g., "crypto/rand", "getrandom").
+	// It is non-secret and suitable for logging and diagnostics.
+	Name() string
+}
+
+// ReadinessChecker is an optional interface implemented by an EntropySource that can report
+// whether it is able to deliver entropy without blocking.
+//
+// NewReader uses it together with Config.EntropyTimeout to fail fast, rather than hang, when the
+// source is not yet initialized (for example, the Linux kernel CRNG early at boot).
+type ReadinessChecker interface {
+	// Ready reports whether a Read would return entropy without blocking.
+	// A non-nil error indicates the readiness probe itself failed.
+	Ready() (bool, error)
+}
+
+// SourceReporter is an optional interface implemented by an EntropySource that draws on other sources,
+// such as EntropyChain, to report which of them served a read.
+//
+// The name is recorded as the EntropySource of AuditInstantiate, AuditReseed, and AuditRekey records,
+// so the audit trail names the source that actually supplied each seed.
+type SourceReporter interface {
+	// ReadSource fills b with entropy, or returns an error, and returns the Name of the source that
+	// supplied it.
+	ReadSource(b []byte) (string, error)
+}
+
+// EntropyNotReadyError is returned by NewReader when the configured EntropySource does not
+// become ready within Config.EntropyTimeout.
+//
+// It matches ErrEntropyNotReady via errors.Is.
+type EntropyNotReadyError struct {
+	// Source is the Name of the entropy source that was not ready.
+	Source string
+
+	// Timeout is the configured readiness timeout that elapsed.
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *EntropyNotReadyError) Error() string {
+	return fmt.Sprintf("ctrdrbg: entropy source %q not ready after %s", e.Source, e.Timeout)
+}
+
+// Unwrap returns ErrEntropyNotReady so callers can match with errors.Is.
+func (e *EntropyNotReadyError) Unwrap() error {
+	return ErrEntropyNotReady
+}
+
+// systemSource is the default EntropySource backed by crypto/rand.Reader.
+type systemSource struct{}
+
+// Read fills b from crypto/rand.Reader.
+func (systemSource) Read(b []byte) (int, error) {
+	return io.ReadFull(rand.Reader, b)
+}
+
+// Name returns "crypto/rand".
+func (systemSource) Name() string {
+	return "crypto/rand"
+}
+
+// Ready reports whether the operating system entropy pool backing crypto/rand is initialized.
+// On platforms without a non-blocking probe, it always reports true.
+func (systemSource) Ready() (bool, error) {
+	return systemEntropyReady()
+}
+
+// entropySource returns the configured EntropySource, or the crypto/rand-backed default if unset.
+func (cfg *Config) entropySource() EntropySource {
+	if cfg.EntropySource != nil {
+		return cfg.EntropySource
+	}
+	return systemSource{}
+}
+
+// readEntropy fills seed with entropy input from the configured source, via the shard's
+// prefetch buffer when EntropyPrefetchSize is set, and returns the name of the source that supplied it.
+func readEntropy(cfg *Config, seed []byte) (string, error) {
+	var src EntropySource = cfg.entropySource()
+	if cfg.prefetch != nil {
+		src = cfg.prefetch
+	}
+	return readSource(src, seed)
+}
+
+// readSource fills b from src and returns the name of the source that supplied it: the member that
+// served the read if src is a SourceReporter, and src itself otherwise.
+func readSource(src EntropySource, b []byte) (string, error) {
+	if sr, ok := src.(SourceReporter); ok {
+		return sr.ReadSource(b)
+	}
+	if _, err := readFull(src, b); err != nil {
+		return "", err
+	}
+	return src.Name(), nil
+}
+
+// supplementalInputLen is the number of bytes drawn from Config.AdditionalInputSource per instantiate or reseed.
+const supplementalInputLen = 64
+
+// readSupplemental fills buf from the configured additional input source and returns it, or returns nil
+// if no source is configured or the read fails. Supplemental input is never credited as entropy, so a
+// failure only omits it and never blocks instantiation or reseeding.
+func readSupplemental(cfg *Config, buf []byte) []byte {
+	if cfg.AdditionalInputSource == nil {
+		return nil
+	}
+	if _, err := io.ReadFull(cfg.AdditionalInputSource, buf); err != nil {
+		clear(buf)
+		return nil
+	}
+	return buf
+}
+
+const (
+	// entropyPollMin is the initial delay between readiness probes.
+	entropyPollMin = time.Millisecond
+
+	// entropyPollMax bounds the delay between readiness probes.
+	entropyPollMax = 100 * time.Millisecond
+)
+
+// waitForEntropy blocks until src reports ready or timeout elapses.
+//
+// A zero timeout disables the readiness wait entirely, preserving the historical behavior of blocking
+// in the first entropy read. Sources that do not implement ReadinessChecker are assumed to be ready.
+//
+// Returns *EntropyNotReadyError if the timeout elapses, or the probe error if readiness cannot be determined.
+func waitForEntropy(src EntropySource, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+	rc, ok := src.(ReadinessChecker)
+	if !ok {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	delay := entropyPollMin
+	for {
+		ready, err := rc.Ready()
+		if err != nil {
+			return fmt.Errorf("ctrdrbg: entropy readiness probe for %q failed: %w", src.Name(), err)
+		}
+		if ready {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return &EntropyNotReadyError{Source: src.Name(), Timeout: timeout}
+		}
+		if delay > remaining {
+			delay = remaining
+		}
+		time.Sleep(delay)
+		delay *= 2
+		if delay > entropyPollMax {
+			delay = entropyPollMax
+		}
+	}
+}
@@ -0,0 +1,549 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrBeaconVerification indicates that a beacon pulse failed signature, output hash, chain, or freshness checks.
+	ErrBeaconVerification = errors.New("ctrdrbg: beacon pulse verification failed")
+
+	// ErrUncreditedSource is returned when a source that does not provide full entropy is configured as the
+	// primary EntropySource. Such sources may only be used via WithAdditionalInputSource.
+	ErrUncreditedSource = errors.New("ctrdrbg: entropy source is not credited with full entropy")
+)
+
+const (
+	// beaconPreviousType is the listValues type carrying the previous pulse's outputValue.
+	beaconPreviousType = "previous"
+
+	// beaconTimeLayout is the timeStamp format covered by the pulse signature.
+	beaconTimeLayout = "2006-01-02T15:04:05.000Z"
+
+	// defaultBeaconTimeout bounds a single pulse fetch.
+	defaultBeaconTimeout = 5 * time.Second
+
+	// defaultBeaconRetryBackoff is the interval after a failed fetch during which the failure is reported
+	// without contacting the beacon again.
+	defaultBeaconRetryBackoff = 10 * time.Second
+
+	// maxBeaconResponse caps the size of a pulse response body.
+	maxBeaconResponse = 1 << 20
+)
+
+// EntropyCreditor is an optional interface implemented by an EntropySource to declare whether its output
+// may be credited as full entropy.
+//
+// Sources that report false (such as BeaconSource) are rejected as the primary EntropySource and may only be
+// mixed in as additional input via WithAdditionalInputSource. Sources that do not implement EntropyCreditor
+// are assumed to provide full entropy.
+type EntropyCreditor interface {
+	// FullEntropy reports whether the source's output may be credited as full entropy.
+	FullEntropy() bool
+}
+
+// isCredited reports whether src may be credited as full entropy.
+func isCredited(src EntropySource) bool {
+	if c, ok := src.(EntropyCreditor); ok {
+		return c.FullEntropy()
+	}
+	return true
+}
+
+// BeaconTransport performs HTTP requests for a BeaconSource. *http.Client satisfies it, allowing proxies,
+// mTLS, or an offline stand-in to be plugged in.
+type BeaconTransport interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// PulseVerifier verifies a beacon pulse signature over the pulse's canonical serialization.
+type PulseVerifier interface {
+	VerifyPulse(message, signature []byte) error
+}
+
+// rsaPulseVerifier verifies RSA PKCS #1 v1.5 signatures over SHA-512, as used by the NIST beacon.
+type rsaPulseVerifier struct{ pub *rsa.PublicKey }
+
+// NewRSAPulseVerifier returns a PulseVerifier for RSA PKCS #1 v1.5 signatures with SHA-512,
+// the scheme used by the NIST Randomness Beacon 2.0.
+func NewRSAPulseVerifier(pub *rsa.PublicKey) PulseVerifier {
+	return rsaPulseVerifier{pub: pub}
+}
+
+// VerifyPulse implements PulseVerifier.
+func (v rsaPulseVerifier) VerifyPulse(message, signature []byte) error {
+	sum := sha512.Sum512(message)
+	return rsa.VerifyPKCS1v15(v.pub, crypto.SHA512, sum[:], signature)
+}
+
+// ed25519PulseVerifier verifies Ed25519 signatures.
+type ed25519PulseVerifier struct{ pub ed25519.PublicKey }
+
+// NewEd25519PulseVerifier returns a PulseVerifier for Ed25519 signatures.
+func NewEd25519PulseVerifier(pub ed25519.PublicKey) PulseVerifier {
+	return ed25519PulseVerifier{pub: pub}
+}
+
+// VerifyPulse implements PulseVerifier.
+func (v ed25519PulseVerifier) VerifyPulse(message, signature []byte) error {
+	if !ed25519.Verify(v.pub, message, signature) {
+		return errors.New("ed25519 signature mismatch")
+	}
+	return nil
+}
+
+// beaconListTypes are the listValues types covered by the pulse signature, in serialization order.
+var beaconListTypes = [...]string{beaconPreviousType, "hour", "day", "month", "year"}
+
+// NewCertificatePulseVerifier returns a PulseVerifier for the public key of a beacon's signing certificate,
+// as published by the NIST Randomness Beacon at /certificate/{certificateId}. RSA keys are verified with
+// PKCS #1 v1.5 over SHA-512 and Ed25519 keys with Ed25519.
+//
+// Returns an error if the certificate's key is of any other type.
+func NewCertificatePulseVerifier(cert *x509.Certificate) (PulseVerifier, error) {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return NewRSAPulseVerifier(pub), nil
+	case ed25519.PublicKey:
+		return NewEd25519PulseVerifier(pub), nil
+	default:
+		return nil, fmt.Errorf("ctrdrbg: unsupported beacon certificate key type %T", cert.PublicKey)
+	}
+}
+
+// BeaconExternal is a pulse's external value field.
+type BeaconExternal struct {
+	SourceID   string `json:"sourceId"`
+	StatusCode uint32 `json:"statusCode"`
+	Value      string `json:"value"`
+}
+
+// BeaconListValue is an entry in a pulse's listValues array.
+type BeaconListValue struct {
+	URI   string `json:"uri"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// BeaconPulse is a NIST-beacon-style randomness pulse. Hex-encoded fields use the same names as the
+// NIST Randomness Beacon 2.0 JSON format.
+type BeaconPulse struct {
+	URI                string            `json:"uri"`
+	Version            string            `json:"version"`
+	CipherSuite        uint32            `json:"cipherSuite"`
+	Period             uint32            `json:"period"`
+	CertificateID      string            `json:"certificateId"`
+	ChainIndex         uint64            `json:"chainIndex"`
+	PulseIndex         uint64            `json:"pulseIndex"`
+	TimeStamp          time.Time         `json:"timeStamp"`
+	LocalRandomValue   string            `json:"localRandomValue"`
+	External           BeaconExternal    `json:"external"`
+	ListValues         []BeaconListValue `json:"listValues"`
+	PrecommitmentValue string            `json:"precommitmentValue"`
+	StatusCode         uint32            `json:"statusCode"`
+	SignatureValue     string            `json:"signatureValue"`
+	OutputValue        string            `json:"outputValue"`
+}
+
+// beaconEnvelope is the top-level JSON document served by the beacon.
+type beaconEnvelope struct {
+	Pulse BeaconPulse `json:"pulse"`
+}
+
+// listValue returns the hex-encoded listValues entry of the given type, and whether it is present.
+func (p *BeaconPulse) listValue(typ string) (string, bool) {
+	for _, lv := range p.ListValues {
+		if lv.Type == typ {
+			return lv.Value, true
+		}
+	}
+	return "", false
+}
+
+// previous returns the hex-encoded previous outputValue from listValues, if present.
+func (p *BeaconPulse) previous() string {
+	v, _ := p.listValue(beaconPreviousType)
+	return v
+}
+
+// signedMessage returns the canonical serialization of the pulse fields covered by the signature, in the
+// NIST Randomness Beacon 2.0 order: uri, version, cipherSuite, period, certificateId, chainIndex, pulseIndex,
+// timeStamp, localRandomValue, external.sourceId, external.statusCode, external.value, the previous, hour,
+// day, month, and year listValues, precommitmentValue, and statusCode.
+//
+// Strings and byte fields (hex-decoded) are prefixed with their length as a big-endian uint32; integers are
+// big-endian. Returns an error if a hex field is malformed or a listValues entry is missing.
+func (p *BeaconPulse) signedMessage() ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	putBytes := func(b []byte) {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+	putHex := func(field, s string) {
+		if err != nil {
+			return
+		}
+		var b []byte
+		if b, err = hex.DecodeString(s); err != nil {
+			err = fmt.Errorf("%s: %w", field, err)
+			return
+		}
+		putBytes(b)
+	}
+
+	putBytes([]byte(p.URI))
+	putBytes([]byte(p.Version))
+	_ = binary.Write(&buf, binary.BigEndian, p.CipherSuite)
+	_ = binary.Write(&buf, binary.BigEndian, p.Period)
+	putHex("certificateId", p.CertificateID)
+	_ = binary.Write(&buf, binary.BigEndian, p.ChainIndex)
+	_ = binary.Write(&buf, binary.BigEndian, p.PulseIndex)
+	putBytes([]byte(p.TimeStamp.UTC().Format(beaconTimeLayout)))
+	putHex("localRandomValue", p.LocalRandomValue)
+	putHex("external.sourceId", p.External.SourceID)
+	_ = binary.Write(&buf, binary.BigEndian, p.External.StatusCode)
+	putHex("external.value", p.External.Value)
+	for _, typ := range beaconListTypes {
+		v, ok := p.listValue(typ)
+		if !ok && err == nil {
+			err = fmt.Errorf("missing %q listValue", typ)
+		}
+		putHex(typ, v)
+	}
+	putHex("precommitmentValue", p.PrecommitmentValue)
+	_ = binary.Write(&buf, binary.BigEndian, p.StatusCode)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// outputFor computes outputValue = SHA-512(message || len(signature) || signature).
+func outputFor(message, signature []byte) [sha512.Size]byte {
+	h := sha512.New()
+	h.Write(message)
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(signature)))
+	h.Write(l[:])
+	h.Write(signature)
+	var out [sha512.Size]byte
+	h.Sum(out[:0])
+	return out
+}
+
+// BeaconConfig configures a BeaconSource.
+type BeaconConfig struct {
+	// URL is the beacon base URL (e.g., "https://beacon.nist.gov/beacon/2.0"). The latest pulse is fetched
+	// from URL + "/pulse/last" and a specific pulse from URL + "/chain/{c}/pulse/{p}".
+	URL string
+
+	// Transport performs HTTP requests. If nil, an *http.Client with Timeout is used.
+	Transport BeaconTransport
+
+	// Verifier checks pulse signatures. Required.
+	Verifier PulseVerifier
+
+	// MaxAge rejects pulses whose timeStamp is older than this. If zero, pulses older than
+	// twice their period are rejected.
+	MaxAge time.Duration
+
+	// Timeout bounds each fetch. If zero, a default of 5 seconds is used.
+	Timeout time.Duration
+
+	// RetryBackoff is the interval after a failed fetch or verification during which reads return that
+	// failure without contacting the beacon again. If zero, a default of 10 seconds is used.
+	RetryBackoff time.Duration
+}
+
+// BeaconSource is an EntropySource that fetches and verifies pulses from an HTTP randomness beacon.
+//
+// Each pulse is checked for a valid signature, a correct outputValue hash, hash-chain linkage to the
+// previously verified pulse (fetching the predecessor when pulses were skipped), and freshness. Verified
+// output is expanded with SHA-512 to the requested length.
+//
+// Beacon output is public, so BeaconSource is never credited as full entropy: it reports FullEntropy() == false
+// and may only be mixed in as additional input via WithAdditionalInputSource. A pulse is cached until its
+// period elapses, so reseeds do not each incur a network round trip. A failed fetch is not retried until
+// RetryBackoff elapses; reads in the meantime return the failure, so a BeaconSource in an EntropyChain
+// defers to the next source rather than stalling every reseed on an unreachable beacon.
+//
+// BeaconSource is safe for concurrent use. At most one fetch is in flight at a time, and no lock is held
+// while it runs.
+type BeaconSource struct {
+	cfg BeaconConfig
+
+	// mu guards the fields below.
+	mu      sync.Mutex
+	last    *BeaconPulse
+	output  [sha512.Size]byte
+	fetched time.Time
+	counter uint64
+
+	// failed and err record the time and cause of the most recent failed refresh.
+	failed time.Time
+	err    error
+
+	// refreshing is non-nil while a refresh is in flight, and is closed when it completes.
+	refreshing chan struct{}
+}
+
+// NewBeaconSource returns a BeaconSource for the given configuration.
+//
+// Returns an error if URL or Verifier is missing.
+func NewBeaconSource(cfg BeaconConfig) (*BeaconSource, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("ctrdrbg: beacon URL is required")
+	}
+	if cfg.Verifier == nil {
+		return nil, errors.New("ctrdrbg: beacon pulse verifier is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultBeaconTimeout
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultBeaconRetryBackoff
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = &http.Client{Timeout: cfg.Timeout}
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &BeaconSource{cfg: cfg}, nil
+}
+
+// Name returns "beacon(<host>)".
+func (s *BeaconSource) Name() string {
+	if u, err := url.Parse(s.cfg.URL); err == nil && u.Host != "" {
+		return "beacon(" + u.Host + ")"
+	}
+	return "beacon"
+}
+
+// FullEntropy reports false: beacon output is public and is never credited as entropy.
+func (s *BeaconSource) FullEntropy() bool {
+	return false
+}
+
+// Last returns a copy of the most recently verified pulse, or nil if none has been fetched.
+func (s *BeaconSource) Last() *BeaconPulse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		return nil
+	}
+	p := *s.last
+	return &p
+}
+
+// Read fills b with SHA-512 expansions of the latest verified pulse output.
+//
+// A new pulse is fetched when the cached pulse's period has elapsed. Each call uses a distinct expansion
+// counter, so consecutive reads from the same pulse differ.
+func (s *BeaconSource) Read(b []byte) (int, error) {
+	output, counter, err := s.current(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	var hdr [16]byte
+	binary.BigEndian.PutUint64(hdr[:8], counter)
+	n := 0
+	for block := uint64(0); n < len(b); block++ {
+		binary.BigEndian.PutUint64(hdr[8:], block)
+		h := sha512.New()
+		h.Write(output[:])
+		h.Write(hdr[:])
+		var sum [sha512.Size]byte
+		n += copy(b[n:], h.Sum(sum[:0]))
+	}
+	return n, nil
+}
+
+// current returns the verified output of a fresh pulse and a new expansion counter, refreshing the pulse
+// if its period has elapsed.
+//
+// Concurrent callers share a single refresh. Within RetryBackoff of a failed refresh, the failure is
+// returned without another fetch.
+func (s *BeaconSource) current(ctx context.Context) ([sha512.Size]byte, uint64, error) {
+	s.mu.Lock()
+	for {
+		if s.fresh() {
+			s.counter++
+			output, counter := s.output, s.counter
+			s.mu.Unlock()
+			return output, counter, nil
+		}
+		if s.err != nil && time.Since(s.failed) < s.cfg.RetryBackoff {
+			err := s.err
+			s.mu.Unlock()
+			return [sha512.Size]byte{}, 0, err
+		}
+		if s.refreshing == nil {
+			break
+		}
+		done := s.refreshing
+		s.mu.Unlock()
+		<-done
+		s.mu.Lock()
+	}
+
+	done := make(chan struct{})
+	s.refreshing = done
+	prev := s.last
+	s.mu.Unlock()
+
+	p, out, err := s.refresh(ctx, prev)
+
+	s.mu.Lock()
+	s.refreshing = nil
+	close(done)
+	if err != nil {
+		s.err, s.failed = err, time.Now()
+		s.mu.Unlock()
+		return [sha512.Size]byte{}, 0, err
+	}
+	s.last, s.output, s.fetched, s.err = p, out, time.Now(), nil
+	s.counter++
+	output, counter := s.output, s.counter
+	s.mu.Unlock()
+	return output, counter, nil
+}
+
+// fresh reports whether the cached pulse may still be used. s.mu must be held.
+func (s *BeaconSource) fresh() bool {
+	if s.last == nil {
+		return false
+	}
+	period := time.Duration(s.last.Period) * time.Millisecond
+	return period > 0 && time.Since(s.fetched) < period
+}
+
+// refresh fetches the latest pulse and verifies it against prev, the most recently verified pulse (or nil).
+// It is called without s.mu held.
+func (s *BeaconSource) refresh(ctx context.Context, prev *BeaconPulse) (*BeaconPulse, [sha512.Size]byte, error) {
+	p, err := s.fetch(ctx, s.cfg.URL+"/pulse/last")
+	if err != nil {
+		return nil, [sha512.Size]byte{}, err
+	}
+	out, err := s.verify(ctx, p, prev)
+	if err != nil {
+		return nil, out, err
+	}
+	return p, out, nil
+}
+
+// verify checks signature, outputValue, freshness, and chain linkage of p to prev, which may be nil.
+func (s *BeaconSource) verify(ctx context.Context, p, prev *BeaconPulse) ([sha512.Size]byte, error) {
+	out, err := s.verifyPulse(p)
+	if err != nil {
+		return out, err
+	}
+
+	maxAge := s.cfg.MaxAge
+	if maxAge <= 0 {
+		maxAge = 2 * time.Duration(p.Period) * time.Millisecond
+	}
+	if maxAge > 0 && time.Since(p.TimeStamp) > maxAge {
+		return out, fmt.Errorf("%w: pulse %d is stale (%s old)", ErrBeaconVerification, p.PulseIndex, time.Since(p.TimeStamp).Round(time.Second))
+	}
+
+	// Hash-chain linkage: the pulse must commit to its predecessor's outputValue.
+	if prev != nil && prev.ChainIndex == p.ChainIndex {
+		if p.PulseIndex <= prev.PulseIndex {
+			if p.PulseIndex == prev.PulseIndex && p.OutputValue == prev.OutputValue {
+				return out, nil
+			}
+			return out, fmt.Errorf("%w: pulse index %d does not advance past %d", ErrBeaconVerification, p.PulseIndex, prev.PulseIndex)
+		}
+		if p.PulseIndex != prev.PulseIndex+1 {
+			prev, err = s.fetch(ctx, fmt.Sprintf("%s/chain/%d/pulse/%d", s.cfg.URL, p.ChainIndex, p.PulseIndex-1))
+			if err != nil {
+				return out, err
+			}
+			if _, err = s.verifyPulse(prev); err != nil {
+				return out, err
+			}
+		}
+		if !strings.EqualFold(p.previous(), prev.OutputValue) {
+			return out, fmt.Errorf("%w: pulse %d does not chain to pulse %d", ErrBeaconVerification, p.PulseIndex, prev.PulseIndex)
+		}
+	}
+
+	return out, nil
+}
+
+// verifyPulse checks the signature and outputValue of p in isolation.
+func (s *BeaconSource) verifyPulse(p *BeaconPulse) ([sha512.Size]byte, error) {
+	var out [sha512.Size]byte
+
+	msg, err := p.signedMessage()
+	if err != nil {
+		return out, fmt.Errorf("%w: malformed pulse %d: %w", ErrBeaconVerification, p.PulseIndex, err)
+	}
+	sig, err := hex.DecodeString(p.SignatureValue)
+	if err != nil {
+		return out, fmt.Errorf("%w: malformed signature on pulse %d: %w", ErrBeaconVerification, p.PulseIndex, err)
+	}
+	if err = s.cfg.Verifier.VerifyPulse(msg, sig); err != nil {
+		return out, fmt.Errorf("%w: bad signature on pulse %d: %w", ErrBeaconVerification, p.PulseIndex, err)
+	}
+
+	out = outputFor(msg, sig)
+	claimed, err := hex.DecodeString(p.OutputValue)
+	if err != nil || !bytes.Equal(claimed, out[:]) {
+		return out, fmt.Errorf("%w: outputValue mismatch on pulse %d", ErrBeaconVerification, p.PulseIndex)
+	}
+	return out, nil
+}
+
+// fetch retrieves and decodes a pulse from u.
+func (s *BeaconSource) fetch(ctx context.Context, u string) (*BeaconPulse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.cfg.Transport.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ctrdrbg: beacon fetch failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ctrdrbg: beacon fetch failed: %s", resp.Status)
+	}
+
+	var env beaconEnvelope
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxBeaconResponse)).Decode(&env); err != nil {
+		return nil, fmt.Errorf("ctrdrbg: beacon response decode failed: %w", err)
+	}
+	return &env.Pulse, nil
+}
@@ -0,0 +1,551 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// beaconStub is an offline stand-in for an HTTP randomness beacon that serves a signed, hash-chained
+// sequence of pulses.
+type beaconStub struct {
+	t      *testing.T
+	priv   ed25519.PrivateKey
+	pub    ed25519.PublicKey
+	period uint32
+	skew   time.Duration
+
+	mu     sync.Mutex
+	pulses []BeaconPulse
+	tamper func(*BeaconPulse)
+	hits   atomic.Int32
+	fail   atomic.Bool
+}
+
+func newBeaconStub(t *testing.T, period time.Duration) *beaconStub {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &beaconStub{t: t, priv: priv, pub: pub, period: uint32(period / time.Millisecond)}
+	s.advance()
+	return s
+}
+
+// advance appends a new pulse chained to the previous one.
+func (s *beaconStub) advance() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	local := make([]byte, 64)
+	_, _ = rand.Read(local)
+	pre := make([]byte, 64)
+	_, _ = rand.Read(pre)
+	prev := strings.Repeat("00", 64)
+	if n := len(s.pulses); n > 0 {
+		prev = s.pulses[n-1].OutputValue
+	}
+
+	p := BeaconPulse{
+		URI:                fmt.Sprintf("stub://chain/1/pulse/%d", len(s.pulses)+1),
+		Version:            "Version 2.0",
+		Period:             s.period,
+		CertificateID:      strings.Repeat("11", 64),
+		ChainIndex:         1,
+		PulseIndex:         uint64(len(s.pulses) + 1),
+		TimeStamp:          time.Now().Add(-s.skew).UTC(),
+		LocalRandomValue:   hex.EncodeToString(local),
+		External:           BeaconExternal{SourceID: strings.Repeat("00", 64), Value: strings.Repeat("00", 64)},
+		PrecommitmentValue: hex.EncodeToString(pre),
+	}
+	for _, typ := range beaconListTypes {
+		p.ListValues = append(p.ListValues, BeaconListValue{Type: typ, Value: prev})
+	}
+	msg, err := p.signedMessage()
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	sig := ed25519.Sign(s.priv, msg)
+	out := outputFor(msg, sig)
+	p.SignatureValue = hex.EncodeToString(sig)
+	p.OutputValue = hex.EncodeToString(out[:])
+	s.pulses = append(s.pulses, p)
+}
+
+func (s *beaconStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.hits.Add(1)
+	if s.fail.Load() {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var p BeaconPulse
+	switch {
+	case r.URL.Path == "/pulse/last":
+		p = s.pulses[len(s.pulses)-1]
+	default:
+		var chain, idx int
+		if _, err := fmt.Sscanf(r.URL.Path, "/chain/%d/pulse/%d", &chain, &idx); err != nil || idx < 1 || idx > len(s.pulses) {
+			http.NotFound(w, r)
+			return
+		}
+		p = s.pulses[idx-1]
+	}
+	if s.tamper != nil {
+		s.tamper(&p)
+	}
+	_ = json.NewEncoder(w).Encode(beaconEnvelope{Pulse: p})
+}
+
+// Test_BeaconSource_Validation verifies constructor argument checks.
+func Test_BeaconSource_Validation(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	_, err := NewBeaconSource(BeaconConfig{})
+	is.Error(err)
+
+	_, err = NewBeaconSource(BeaconConfig{URL: "http://example.invalid"})
+	is.Error(err)
+}
+
+// Test_BeaconSource_FetchAndVerify verifies that a signed pulse is fetched, verified, cached, and expanded.
+func Test_BeaconSource_FetchAndVerify(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Minute)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: NewEd25519PulseVerifier(stub.pub)})
+	is.NoError(err)
+	is.False(src.FullEntropy())
+	is.True(strings.HasPrefix(src.Name(), "beacon("))
+
+	buf1 := make([]byte, 100)
+	buf2 := make([]byte, 100)
+	_, err = src.Read(buf1)
+	is.NoError(err)
+	_, err = src.Read(buf2)
+	is.NoError(err)
+	is.False(bytes.Equal(buf1, buf2), "each read should use a distinct expansion")
+	is.Equal(int32(1), stub.hits.Load(), "pulse should be cached for its period")
+	is.Equal(uint64(1), src.Last().PulseIndex)
+}
+
+// Test_BeaconSource_Chain verifies chain linkage across consecutive and skipped pulses.
+func Test_BeaconSource_Chain(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Millisecond)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	src, err := NewBeaconSource(BeaconConfig{
+		URL:       srv.URL,
+		Transport: srv.Client(),
+		Verifier:  NewEd25519PulseVerifier(stub.pub),
+		MaxAge:    time.Minute,
+	})
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = src.Read(buf)
+	is.NoError(err)
+
+	// Consecutive pulse.
+	stub.advance()
+	time.Sleep(2 * time.Millisecond)
+	_, err = src.Read(buf)
+	is.NoError(err)
+	is.Equal(uint64(2), src.Last().PulseIndex)
+
+	// Skipped pulses: the predecessor is fetched to verify linkage.
+	stub.advance()
+	stub.advance()
+	time.Sleep(2 * time.Millisecond)
+	hits := stub.hits.Load()
+	_, err = src.Read(buf)
+	is.NoError(err)
+	is.Equal(uint64(4), src.Last().PulseIndex)
+	is.Equal(hits+2, stub.hits.Load(), "predecessor should be fetched for a gap")
+}
+
+// Test_BeaconSource_Rejects verifies that tampered, forged, and stale pulses are rejected.
+func Test_BeaconSource_Rejects(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		tamper func(*BeaconPulse)
+	}{
+		{"BadSignature", func(p *BeaconPulse) { p.SignatureValue = strings.Repeat("ab", 64) }},
+		{"BadOutput", func(p *BeaconPulse) { p.OutputValue = strings.Repeat("cd", 64) }},
+		{"ModifiedField", func(p *BeaconPulse) { p.LocalRandomValue = strings.Repeat("ef", 64) }},
+		{"Stale", nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			stub := newBeaconStub(t, time.Minute)
+			stub.tamper = tc.tamper
+			if tc.tamper == nil {
+				// Validly signed, but older than twice the period.
+				stub.pulses = nil
+				stub.skew = time.Hour
+				stub.advance()
+			}
+			srv := httptest.NewServer(stub)
+			defer srv.Close()
+
+			src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: NewEd25519PulseVerifier(stub.pub)})
+			is.NoError(err)
+
+			buf := make([]byte, 32)
+			_, err = src.Read(buf)
+			is.ErrorIs(err, ErrBeaconVerification)
+			is.Nil(src.Last())
+		})
+	}
+}
+
+// Test_BeaconSource_BrokenChain verifies that a pulse not committing to its predecessor is rejected.
+func Test_BeaconSource_BrokenChain(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Millisecond)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: NewEd25519PulseVerifier(stub.pub), MaxAge: time.Minute})
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = src.Read(buf)
+	is.NoError(err)
+
+	// Replace the chain with a fresh, validly signed history that does not link to pulse 1.
+	stub.mu.Lock()
+	stub.pulses = nil
+	stub.mu.Unlock()
+	stub.advance()
+	stub.advance()
+	time.Sleep(2 * time.Millisecond)
+
+	_, err = src.Read(buf)
+	is.ErrorIs(err, ErrBeaconVerification)
+	is.Equal(uint64(1), src.Last().PulseIndex)
+}
+
+// Test_BeaconPulse_SignedMessage verifies the NIST Randomness Beacon 2.0 field order and length prefixes.
+func Test_BeaconPulse_SignedMessage(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	p := BeaconPulse{
+		URI:                "u",
+		Version:            "v2",
+		CipherSuite:        0,
+		Period:             60000,
+		CertificateID:      "c1",
+		ChainIndex:         2,
+		PulseIndex:         3,
+		TimeStamp:          time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC),
+		LocalRandomValue:   "a1",
+		External:           BeaconExternal{SourceID: "e1", StatusCode: 4, Value: "e2"},
+		ListValues:         []BeaconListValue{{Type: "year", Value: "f5"}, {Type: "month", Value: "f4"}, {Type: "day", Value: "f3"}, {Type: "hour", Value: "f2"}, {Type: beaconPreviousType, Value: "f1"}},
+		PrecommitmentValue: "b1",
+		StatusCode:         5,
+	}
+
+	ts := "2026-01-02T03:04:00.000Z"
+	want := bytes.Join([][]byte{
+		{0, 0, 0, 1}, []byte("u"),
+		{0, 0, 0, 2}, []byte("v2"),
+		{0, 0, 0, 0},
+		{0, 0, 0xea, 0x60},
+		{0, 0, 0, 1, 0xc1},
+		{0, 0, 0, 0, 0, 0, 0, 2},
+		{0, 0, 0, 0, 0, 0, 0, 3},
+		{0, 0, 0, byte(len(ts))}, []byte(ts),
+		{0, 0, 0, 1, 0xa1},
+		{0, 0, 0, 1, 0xe1},
+		{0, 0, 0, 4},
+		{0, 0, 0, 1, 0xe2},
+		{0, 0, 0, 1, 0xf1},
+		{0, 0, 0, 1, 0xf2},
+		{0, 0, 0, 1, 0xf3},
+		{0, 0, 0, 1, 0xf4},
+		{0, 0, 0, 1, 0xf5},
+		{0, 0, 0, 1, 0xb1},
+		{0, 0, 0, 5},
+	}, nil)
+
+	msg, err := p.signedMessage()
+	is.NoError(err)
+	is.Equal(want, msg)
+
+	p.ListValues = p.ListValues[1:]
+	_, err = p.signedMessage()
+	is.ErrorContains(err, `missing "year" listValue`)
+}
+
+// beaconCertificatePulse is a pulse in the JSON layout served by the NIST Randomness Beacon 2.0.
+const beaconCertificatePulse = `{
+  "pulse" : {
+    "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/7",
+    "version" : "Version 2.0",
+    "cipherSuite" : 0,
+    "period" : 60000,
+    "certificateId" : "%s",
+    "chainIndex" : 2,
+    "pulseIndex" : 7,
+    "timeStamp" : "%s",
+    "localRandomValue" : "%s",
+    "external" : {
+      "sourceId" : "%s",
+      "statusCode" : 0,
+      "value" : "%s"
+    },
+    "listValues" : [ {
+      "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/6",
+      "type" : "previous",
+      "value" : "%s"
+    }, {
+      "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/1",
+      "type" : "hour",
+      "value" : "%s"
+    }, {
+      "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/1",
+      "type" : "day",
+      "value" : "%s"
+    }, {
+      "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/1",
+      "type" : "month",
+      "value" : "%s"
+    }, {
+      "uri" : "https://beacon.example/beacon/2.0/chain/2/pulse/1",
+      "type" : "year",
+      "value" : "%s"
+    } ],
+    "precommitmentValue" : "%s",
+    "statusCode" : 0,
+    "signatureValue" : "%s",
+    "outputValue" : "%s"
+  }
+}`
+
+// Test_BeaconSource_CertificateVerifier verifies a pulse in the NIST wire format, signed with RSA PKCS #1 v1.5
+// over SHA-512 under an X.509 signing certificate, as the NIST beacon does.
+func Test_BeaconSource_CertificateVerifier(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	is.NoError(err)
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	is.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	is.NoError(err)
+	certID := sha512.Sum512(der)
+
+	hexOf := func(n int) string {
+		b := make([]byte, n)
+		_, _ = rand.Read(b)
+		return hex.EncodeToString(b)
+	}
+	ts := time.Now().UTC().Truncate(time.Minute)
+	fields := []any{hex.EncodeToString(certID[:]), ts.Format(beaconTimeLayout), hexOf(64), hexOf(64), hexOf(64),
+		hexOf(64), hexOf(64), hexOf(64), hexOf(64), hexOf(64), hexOf(64)}
+
+	// Sign the pulse as decoded from the wire format, then serve it with its signature and output.
+	var env beaconEnvelope
+	is.NoError(json.Unmarshal([]byte(fmt.Sprintf(beaconCertificatePulse, append(fields, "", "")...)), &env))
+	msg, err := env.Pulse.signedMessage()
+	is.NoError(err)
+	sum := sha512.Sum512(msg)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, sum[:])
+	is.NoError(err)
+	out := outputFor(msg, sig)
+	body := fmt.Sprintf(beaconCertificatePulse, append(fields, hex.EncodeToString(sig), hex.EncodeToString(out[:]))...)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	verifier, err := NewCertificatePulseVerifier(cert)
+	is.NoError(err)
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: verifier})
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = src.Read(buf)
+	is.NoError(err)
+	is.Equal(uint64(7), src.Last().PulseIndex)
+	is.Equal(fields[0], src.Last().CertificateID)
+
+	// Every signed field is covered: altering the external value invalidates the pulse.
+	is.NoError(json.Unmarshal([]byte(body), &env))
+	env.Pulse.External.Value = hexOf(64)
+	msg, err = env.Pulse.signedMessage()
+	is.NoError(err)
+	is.Error(verifier.VerifyPulse(msg, sig))
+
+	_, err = NewCertificatePulseVerifier(&x509.Certificate{PublicKey: "unsupported"})
+	is.Error(err)
+}
+
+// Test_BeaconSource_RetryBackoff verifies that a failed fetch is reported without contacting the beacon again
+// until RetryBackoff elapses.
+func Test_BeaconSource_RetryBackoff(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Minute)
+	stub.fail.Store(true)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	const backoff = 50 * time.Millisecond
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: NewEd25519PulseVerifier(stub.pub), RetryBackoff: backoff})
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = src.Read(buf)
+	is.ErrorContains(err, "503")
+	_, err2 := src.Read(buf)
+	is.Equal(err, err2, "failure should be served during the backoff")
+	is.Equal(int32(1), stub.hits.Load(), "beacon should not be contacted during the backoff")
+
+	stub.fail.Store(false)
+	time.Sleep(backoff)
+	_, err = src.Read(buf)
+	is.NoError(err)
+	is.Equal(int32(2), stub.hits.Load())
+}
+
+// gatedTransport blocks each request until release is closed, signalling entered when a request arrives.
+type gatedTransport struct {
+	next    BeaconTransport
+	entered chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (g *gatedTransport) Do(req *http.Request) (*http.Response, error) {
+	if g.calls.Add(1) == 1 {
+		close(g.entered)
+	}
+	<-g.release
+	return g.next.Do(req)
+}
+
+// Test_BeaconSource_ConcurrentRefresh verifies that a fetch runs without holding the source's lock and is shared
+// by concurrent readers.
+func Test_BeaconSource_ConcurrentRefresh(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Minute)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	gate := &gatedTransport{next: srv.Client(), entered: make(chan struct{}), release: make(chan struct{})}
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: gate, Verifier: NewEd25519PulseVerifier(stub.pub)})
+	is.NoError(err)
+
+	const readers = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 32)
+			_, err := src.Read(buf)
+			errs <- err
+		}()
+	}
+
+	<-gate.entered
+	is.Nil(src.Last(), "Last should not wait for an in-flight fetch")
+	close(gate.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		is.NoError(err)
+	}
+	is.Equal(int32(1), gate.calls.Load(), "concurrent readers should share one fetch")
+}
+
+// Test_BeaconSource_Uncredited verifies that a beacon cannot be the primary entropy source and is accepted
+// only as additional input.
+func Test_BeaconSource_Uncredited(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	stub := newBeaconStub(t, time.Minute)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	src, err := NewBeaconSource(BeaconConfig{URL: srv.URL, Transport: srv.Client(), Verifier: NewEd25519PulseVerifier(stub.pub)})
+	is.NoError(err)
+
+	_, err = NewReader(WithEntropySource(src))
+	is.ErrorIs(err, ErrUncreditedSource)
+
+	_, err = NewEntropyChain([]ChainSource{{Source: src}})
+	is.ErrorIs(err, ErrUncreditedSource)
+
+	rdr, err := NewReader(WithAdditionalInputSource(src), WithShards(1))
+	is.NoError(err)
+	is.Equal(int32(1), stub.hits.Load(), "beacon should be consulted at instantiation")
+
+	buf := make([]byte, 32)
+	_, err = rdr.Read(buf)
+	is.NoError(err)
+}
+
+// Test_AdditionalInputSource_FailureIgnored verifies that supplemental source failures never block seeding.
+func Test_AdditionalInputSource_FailureIgnored(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithAdditionalInputSource(failingSource{}))
+	is.NoError(err)
+	is.NoError(rdr.Reseed(nil))
+}
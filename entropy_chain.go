@@ -0,0 +1,481 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrEntropyChainExhausted is returned when every source in an EntropyChain failed or is unhealthy.
+	ErrEntropyChainExhausted = errors.New("ctrdrbg: all entropy sources in chain failed")
+
+	// ErrSourceHealthTestFailed indicates that an entropy source failed its continuous health tests.
+	ErrSourceHealthTestFailed = errors.New("ctrdrbg: entropy source health test failed")
+)
+
+// SP 800-90B §4.4 continuous health test parameters for byte-valued samples. The cutoffs are derived
+// from each source's assessed min-entropy per sample (see ChainSource.MinEntropy) by rctCutoffFor and
+// aptCutoffFor.
+const (
+	// fullEntropy is the min-entropy, in bits, of a byte sample from a source with full entropy.
+	fullEntropy = 8.0
+
+	// rctAlphaLog2 is -log2 of the Repetition Count Test false-positive probability, α = 2^-40. The test
+	// is evaluated at every sample, so α is kept smaller than for the Adaptive Proportion Test.
+	rctAlphaLog2 = 40
+
+	// aptAlphaLog2 is -log2 of the Adaptive Proportion Test false-positive probability per window,
+	// α = 2^-20, the value used for the cutoffs of SP 800-90B Table 2.
+	aptAlphaLog2 = 20
+
+	// aptWindow is the Adaptive Proportion Test window size for non-binary samples.
+	aptWindow = 512
+)
+
+// rctCutoffFor returns the Repetition Count Test cutoff for samples of h bits of min-entropy:
+// C = 1 + ceil(-log2(α) / h) (SP 800-90B §4.4.1).
+func rctCutoffFor(h float64) int {
+	return 1 + int(math.Ceil(rctAlphaLog2/h))
+}
+
+// aptCutoffFor returns the Adaptive Proportion Test cutoff for samples of h bits of min-entropy:
+// C = 1 + CRITBINOM(W, 2^-h, 1-α) (SP 800-90B §4.4.2), the smallest count of the window's first sample
+// whose probability of being exceeded by a source with h bits of min-entropy is at most α.
+func aptCutoffFor(h float64) int {
+	p := math.Exp2(-h)
+	alpha := math.Exp2(-aptAlphaLog2)
+	lgW, _ := math.Lgamma(aptWindow + 1)
+
+	// Sum the binomial upper tail from the top down, in log space to avoid underflow, until it exceeds α.
+	var tail float64
+	for k := aptWindow; k >= 0; k-- {
+		lgK, _ := math.Lgamma(float64(k) + 1)
+		lgWK, _ := math.Lgamma(float64(aptWindow-k) + 1)
+		tail += math.Exp(lgW - lgK - lgWK + float64(k)*math.Log(p) + float64(aptWindow-k)*math.Log1p(-p))
+		if tail > alpha {
+			// P(X >= k) > α, so CRITBINOM(W, p, 1-α) = k and the cutoff is k+1.
+			return k + 1
+		}
+	}
+	return 1
+}
+
+const (
+	// defaultRecoveryInterval is how long an unhealthy source is skipped before it is probed again.
+	defaultRecoveryInterval = 30 * time.Second
+)
+
+// SourceHealth describes the health status of a source within an EntropyChain.
+type SourceHealth int
+
+const (
+	// SourceHealthy indicates the source's last read succeeded and passed its health tests.
+	SourceHealthy SourceHealth = iota
+
+	// SourceUnhealthy indicates the source's last read failed or did not pass its health tests.
+	// The source is skipped until its RecoveryInterval elapses.
+	SourceUnhealthy
+)
+
+// String returns a human-readable name for the health status.
+func (h SourceHealth) String() string {
+	switch h {
+	case SourceHealthy:
+		return "healthy"
+	case SourceUnhealthy:
+		return "unhealthy"
+	default:
+		return fmt.Sprintf("SourceHealth(%d)", int(h))
+	}
+}
+
+// RetryPolicy controls how an EntropyChain retries a single source before failing over.
+type RetryPolicy struct {
+	// MaxAttempts is the number of read attempts made against the source per request before
+	// moving to the next source. If zero, a single attempt is made.
+	MaxAttempts int
+
+	// Backoff is the delay between consecutive attempts against the same source.
+	Backoff time.Duration
+
+	// RecoveryInterval is how long the source is skipped after it becomes unhealthy. Once it
+	// elapses, the source is probed again and, if it succeeds, the chain switches back to it.
+	// If zero, a default of 30 seconds is used.
+	RecoveryInterval time.Duration
+}
+
+// ChainSource pairs an EntropySource with its retry policy for use in an EntropyChain.
+type ChainSource struct {
+	// Source is the entropy source.
+	Source EntropySource
+
+	// Retry controls retries and recovery probing for Source.
+	Retry RetryPolicy
+
+	// MinEntropy is the assessed min-entropy of each output byte of Source, in bits, from which the
+	// cutoffs of its SP 800-90B continuous health tests are derived. It must be in (0, 8]. If zero, the
+	// source is assumed to have full entropy (8 bits per byte).
+	MinEntropy float64
+}
+
+// EntropySwitchEvent reports that an EntropyChain changed its active source.
+type EntropySwitchEvent struct {
+	// From is the name of the previously active source.
+	From string
+
+	// To is the name of the newly active source.
+	To string
+
+	// Reason is the error that caused the failover, or nil when returning to a recovered,
+	// higher-priority source.
+	Reason error
+
+	// Time is when the switch occurred.
+	Time time.Time
+}
+
+// SourceStatus is a point-in-time snapshot of a source's health within an EntropyChain.
+type SourceStatus struct {
+	// Name is the source's Name.
+	Name string
+
+	// Health is the current health status.
+	Health SourceHealth
+
+	// Failures counts reads that errored or failed health tests.
+	Failures uint64
+
+	// LastError is the most recent failure, or nil.
+	LastError error
+}
+
+// ChainOption configures an EntropyChain.
+type ChainOption func(*EntropyChain)
+
+// WithSwitchHandler returns a ChainOption that registers fn to receive an EntropySwitchEvent every
+// time the chain changes its active source. fn is called synchronously after the chain's internal
+// lock is released and must not block for long.
+func WithSwitchHandler(fn func(EntropySwitchEvent)) ChainOption {
+	return func(c *EntropyChain) { c.onSwitch = fn }
+}
+
+// chainMember holds a source's policy, health state, and continuous health test state.
+//
+// The health state (health, retryAt, failures, lastErr) is guarded by the chain's mu, and the health test
+// state by the member's own mu, so that no lock is held while the source is read.
+type chainMember struct {
+	ChainSource
+	health   SourceHealth
+	retryAt  time.Time
+	failures uint64
+	lastErr  error
+
+	// rctCutoff and aptCutoff are the health test cutoffs derived from MinEntropy.
+	rctCutoff int
+	aptCutoff int
+
+	mu        sync.Mutex
+	rctLast   byte
+	rctCount  int
+	aptFirst  byte
+	aptCount  int
+	aptSeen   int
+	rctPrimed bool
+}
+
+// EntropyChain is an EntropySource that reads from an ordered list of sources, failing over to the next
+// source when one errors or fails its SP 800-90B continuous health tests, and switching back to a
+// higher-priority source once it recovers.
+//
+// Sources are tried in order on every read. An unhealthy source is skipped until its RetryPolicy's
+// RecoveryInterval elapses; it is then probed again, and the chain returns to it on success. Every
+// change of active source is reported through the handler registered with WithSwitchHandler.
+//
+// EntropyChain is safe for concurrent use. No lock is held while a source is read or while a retry backs
+// off, so a slow or failing source delays only the reads that are trying it.
+type EntropyChain struct {
+	mu       sync.Mutex
+	members  []*chainMember
+	active   int
+	onSwitch func(EntropySwitchEvent)
+}
+
+// NewEntropyChain returns an EntropyChain over sources in priority order (primary first).
+//
+// Returns an error if no sources are given or any source is nil.
+func NewEntropyChain(sources []ChainSource, opts ...ChainOption) (*EntropyChain, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("ctrdrbg: entropy chain requires at least one source")
+	}
+
+	c := &EntropyChain{members: make([]*chainMember, len(sources))}
+	for i, s := range sources {
+		if s.Source == nil {
+			return nil, fmt.Errorf("ctrdrbg: entropy chain source %d is nil", i)
+		}
+		if !isCredited(s.Source) {
+			return nil, fmt.Errorf("%w: chain source %d (%s)", ErrUncreditedSource, i, s.Source.Name())
+		}
+		h := s.MinEntropy
+		if h == 0 {
+			h = fullEntropy
+		}
+		if !(h > 0 && h <= fullEntropy) {
+			return nil, fmt.Errorf("ctrdrbg: entropy chain source %d (%s): MinEntropy %g must be in (0, 8]", i, s.Source.Name(), s.MinEntropy)
+		}
+		c.members[i] = &chainMember{ChainSource: s, rctCutoff: rctCutoffFor(h), aptCutoff: aptCutoffFor(h)}
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Name returns "chain(a,b,...)" listing member sources in priority order.
+func (c *EntropyChain) Name() string {
+	names := make([]string, len(c.members))
+	for i, m := range c.members {
+		names[i] = m.Source.Name()
+	}
+	return "chain(" + strings.Join(names, ",") + ")"
+}
+
+// Active returns the name of the source that served the most recent successful read.
+func (c *EntropyChain) Active() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.members[c.active].Source.Name()
+}
+
+// Status returns a snapshot of every member's health in priority order.
+func (c *EntropyChain) Status() []SourceStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]SourceStatus, len(c.members))
+	for i, m := range c.members {
+		out[i] = SourceStatus{
+			Name:      m.Source.Name(),
+			Health:    m.health,
+			Failures:  m.failures,
+			LastError: m.lastErr,
+		}
+	}
+	return out
+}
+
+// Ready reports whether at least one member source is ready. Members that do not implement
+// ReadinessChecker are assumed ready.
+func (c *EntropyChain) Ready() (bool, error) {
+	var errs []error
+	for _, m := range c.members {
+		rc, ok := m.Source.(ReadinessChecker)
+		if !ok {
+			return true, nil
+		}
+		ready, err := rc.Ready()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ready {
+			return true, nil
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+// Read fills b from the highest-priority source that is healthy (or due for a recovery probe),
+// succeeds within its retry policy, and passes its continuous health tests.
+//
+// Returns an error wrapping ErrEntropyChainExhausted and every member's failure if no source succeeds.
+func (c *EntropyChain) Read(b []byte) (int, error) {
+	if _, err := c.ReadSource(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// ReadSource implements SourceReporter: it reads as Read does and returns the name of the member that
+// served the read. Unlike Active, the name is specific to this read, even when reads run concurrently.
+func (c *EntropyChain) ReadSource(b []byte) (string, error) {
+	var (
+		errs  []error
+		cause error
+	)
+
+	for i, m := range c.members {
+		if !c.claim(m) {
+			continue
+		}
+
+		err := m.read(b)
+		if err == nil {
+			c.succeeded(i, cause)
+			return m.Source.Name(), nil
+		}
+
+		c.failed(m, err)
+		if i == c.activeIndex() || cause == nil {
+			cause = err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", m.Source.Name(), err))
+	}
+
+	clear(b)
+	return "", fmt.Errorf("%w: %w", ErrEntropyChainExhausted, errors.Join(errs...))
+}
+
+// claim reports whether m should be tried. An unhealthy member is tried only once its recovery interval
+// has elapsed; its next probe is then rescheduled, so that concurrent reads do not all probe it at once.
+func (c *EntropyChain) claim(m *chainMember) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m.health == SourceHealthy {
+		return true
+	}
+	now := time.Now()
+	if now.Before(m.retryAt) {
+		return false
+	}
+	m.retryAt = now.Add(m.recoveryInterval())
+	return true
+}
+
+// succeeded marks member i healthy and makes it the active source, reporting a switch if it was not.
+func (c *EntropyChain) succeeded(i int, cause error) {
+	c.mu.Lock()
+	m := c.members[i]
+	m.health = SourceHealthy
+	var event *EntropySwitchEvent
+	if i != c.active {
+		event = &EntropySwitchEvent{
+			From:   c.members[c.active].Source.Name(),
+			To:     m.Source.Name(),
+			Reason: cause,
+			Time:   time.Now(),
+		}
+		c.active = i
+	}
+	c.mu.Unlock()
+
+	if event != nil && c.onSwitch != nil {
+		c.onSwitch(*event)
+	}
+}
+
+// failed marks m unhealthy after err, skipping it until its recovery interval elapses.
+func (c *EntropyChain) failed(m *chainMember, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m.health = SourceUnhealthy
+	m.failures++
+	m.lastErr = err
+	m.retryAt = time.Now().Add(m.recoveryInterval())
+}
+
+// activeIndex returns the index of the active member.
+func (c *EntropyChain) activeIndex() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active
+}
+
+// recoveryInterval returns how long m is skipped after it becomes unhealthy.
+func (m *chainMember) recoveryInterval() time.Duration {
+	if m.Retry.RecoveryInterval > 0 {
+		return m.Retry.RecoveryInterval
+	}
+	return defaultRecoveryInterval
+}
+
+// read reads b from m, honoring its retry policy, and runs the continuous health tests.
+// On failure, b is cleared.
+func (m *chainMember) read(b []byte) error {
+	attempts := m.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for a := 0; a < attempts; a++ {
+		if a > 0 && m.Retry.Backoff > 0 {
+			time.Sleep(m.Retry.Backoff)
+		}
+		if _, err = readFull(m.Source, b); err != nil {
+			continue
+		}
+		if err = m.healthTest(b); err != nil {
+			clear(b)
+			return err
+		}
+		return nil
+	}
+
+	clear(b)
+	return err
+}
+
+// readFull reads exactly len(b) bytes from src.
+func readFull(src EntropySource, b []byte) (int, error) {
+	n := 0
+	for n < len(b) {
+		m, err := src.Read(b[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+		if m == 0 {
+			return n, errors.New("ctrdrbg: entropy source returned no data")
+		}
+	}
+	return n, nil
+}
+
+// healthTest runs the SP 800-90B §4.4.1 Repetition Count Test and §4.4.2 Adaptive Proportion Test
+// over the byte samples in b. Test state persists across reads from the same source.
+func (m *chainMember) healthTest(b []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range b {
+		// Repetition Count Test.
+		if m.rctPrimed && s == m.rctLast {
+			m.rctCount++
+			if m.rctCount >= m.rctCutoff {
+				m.rctPrimed = false
+				return fmt.Errorf("%w: repetition count test", ErrSourceHealthTestFailed)
+			}
+		} else {
+			m.rctLast, m.rctCount, m.rctPrimed = s, 1, true
+		}
+
+		// Adaptive Proportion Test.
+		if m.aptSeen == 0 {
+			m.aptFirst, m.aptCount = s, 1
+		} else if s == m.aptFirst {
+			m.aptCount++
+			if m.aptCount >= m.aptCutoff {
+				m.aptSeen = 0
+				return fmt.Errorf("%w: adaptive proportion test", ErrSourceHealthTestFailed)
+			}
+		}
+		m.aptSeen++
+		if m.aptSeen == aptWindow {
+			m.aptSeen = 0
+		}
+	}
+	return nil
+}
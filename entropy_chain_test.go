@@ -0,0 +1,345 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// toggleSource is a test EntropySource whose failure mode can be switched at runtime.
+type toggleSource struct {
+	name     string
+	fail     atomic.Bool
+	stuck    atomic.Bool
+	failNext atomic.Int32
+	reads    atomic.Int32
+}
+
+var errToggleSource = errors.New("toggle source failure")
+
+func (s *toggleSource) Read(b []byte) (int, error) {
+	s.reads.Add(1)
+	if s.fail.Load() {
+		return 0, errToggleSource
+	}
+	if s.failNext.Load() > 0 {
+		s.failNext.Add(-1)
+		return 0, errToggleSource
+	}
+	if s.stuck.Load() {
+		clear(b)
+		return len(b), nil
+	}
+	return rand.Read(b)
+}
+
+func (s *toggleSource) Name() string { return s.name }
+
+// switchRecorder collects EntropySwitchEvents.
+type switchRecorder struct {
+	mu     sync.Mutex
+	events []EntropySwitchEvent
+}
+
+func (r *switchRecorder) record(e EntropySwitchEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *switchRecorder) all() []EntropySwitchEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]EntropySwitchEvent(nil), r.events...)
+}
+
+// Test_EntropyChain_Validation verifies constructor argument checks.
+func Test_EntropyChain_Validation(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	_, err := NewEntropyChain(nil)
+	is.Error(err)
+
+	_, err = NewEntropyChain([]ChainSource{{Source: nil}})
+	is.Error(err)
+}
+
+// Test_EntropyChain_Primary verifies that a healthy primary serves all reads without switch events.
+func Test_EntropyChain_Primary(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	backup := &toggleSource{name: "backup"}
+	rec := &switchRecorder{}
+	chain, err := NewEntropyChain([]ChainSource{{Source: primary}, {Source: backup}}, WithSwitchHandler(rec.record))
+	is.NoError(err)
+	is.Equal("chain(primary,backup)", chain.Name())
+
+	buf := make([]byte, 48)
+	for i := 0; i < 10; i++ {
+		_, err = chain.Read(buf)
+		is.NoError(err)
+	}
+	is.Equal(int32(10), primary.reads.Load())
+	is.Equal(int32(0), backup.reads.Load())
+	is.Equal("primary", chain.Active())
+	is.Empty(rec.all())
+}
+
+// Test_EntropyChain_FailoverAndRecovery verifies failover on error and return to the recovered primary.
+func Test_EntropyChain_FailoverAndRecovery(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	backup := &toggleSource{name: "backup"}
+	rec := &switchRecorder{}
+	chain, err := NewEntropyChain([]ChainSource{
+		{Source: primary, Retry: RetryPolicy{RecoveryInterval: 20 * time.Millisecond}},
+		{Source: backup},
+	}, WithSwitchHandler(rec.record))
+	is.NoError(err)
+
+	buf := make([]byte, 48)
+	primary.fail.Store(true)
+	source, err := chain.ReadSource(buf)
+	is.NoError(err)
+	is.Equal("backup", source)
+	is.Equal("backup", chain.Active())
+
+	status := chain.Status()
+	is.Equal(SourceUnhealthy, status[0].Health)
+	is.ErrorIs(status[0].LastError, errToggleSource)
+	is.Equal(uint64(1), status[0].Failures)
+	is.Equal(SourceHealthy, status[1].Health)
+
+	// While within the recovery interval, the primary is skipped entirely.
+	reads := primary.reads.Load()
+	_, err = chain.Read(buf)
+	is.NoError(err)
+	is.Equal(reads, primary.reads.Load())
+
+	// Once the primary recovers and the interval elapses, the chain returns to it.
+	primary.fail.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	_, err = chain.Read(buf)
+	is.NoError(err)
+	is.Equal("primary", chain.Active())
+
+	events := rec.all()
+	is.Len(events, 2)
+	is.Equal("primary", events[0].From)
+	is.Equal("backup", events[0].To)
+	is.ErrorIs(events[0].Reason, errToggleSource)
+	is.Equal("backup", events[1].From)
+	is.Equal("primary", events[1].To)
+	is.NoError(events[1].Reason)
+}
+
+// Test_EntropyChain_HealthTestFailover verifies that a stuck source fails its health tests and is bypassed.
+func Test_EntropyChain_HealthTestFailover(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	primary.stuck.Store(true)
+	backup := &toggleSource{name: "backup"}
+	rec := &switchRecorder{}
+	chain, err := NewEntropyChain([]ChainSource{{Source: primary}, {Source: backup}}, WithSwitchHandler(rec.record))
+	is.NoError(err)
+
+	buf := make([]byte, 48)
+	_, err = chain.Read(buf)
+	is.NoError(err)
+	is.Equal("backup", chain.Active())
+	is.ErrorIs(chain.Status()[0].LastError, ErrSourceHealthTestFailed)
+
+	events := rec.all()
+	is.Len(events, 1)
+	is.ErrorIs(events[0].Reason, ErrSourceHealthTestFailed)
+}
+
+// Test_EntropyChain_Cutoffs verifies that the health test cutoffs derived from the assessed min-entropy
+// match SP 800-90B: the Adaptive Proportion Test cutoffs of Table 2 (W = 512, α = 2^-20) and the
+// Repetition Count Test formula of §4.4.1 (α = 2^-40).
+func Test_EntropyChain_Cutoffs(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	for _, tc := range []struct {
+		h   float64
+		apt int
+		rct int
+	}{
+		{8, 13, 6},
+		{4, 62, 11},
+		{2, 177, 21},
+		{1, 311, 41},
+		{0.5, 410, 81},
+	} {
+		is.Equal(tc.apt, aptCutoffFor(tc.h), "APT cutoff for H = %g", tc.h)
+		is.Equal(tc.rct, rctCutoffFor(tc.h), "RCT cutoff for H = %g", tc.h)
+	}
+
+	for _, h := range []float64{-1, 8.5} {
+		_, err := NewEntropyChain([]ChainSource{{Source: &toggleSource{name: "a"}, MinEntropy: h}})
+		is.Error(err, "MinEntropy %g", h)
+	}
+}
+
+// biasedSource is a test EntropySource that emits zero at every 32nd byte and distinct nonzero bytes
+// otherwise: 16 zeros per 512-byte window, 8 times the 2 expected from a uniform source, with no
+// repetitions for the Repetition Count Test to detect.
+type biasedSource struct {
+	pos int
+}
+
+func (s *biasedSource) Read(b []byte) (int, error) {
+	for i := range b {
+		if s.pos%32 == 0 {
+			b[i] = 0
+		} else {
+			b[i] = byte(s.pos%255) + 1
+		}
+		s.pos++
+	}
+	return len(b), nil
+}
+
+func (s *biasedSource) Name() string { return "biased" }
+
+// Test_EntropyChain_BiasedSource verifies that the Adaptive Proportion Test detects a biased source
+// that the former fixed cutoff of 20 accepted.
+func Test_EntropyChain_BiasedSource(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	backup := &toggleSource{name: "backup"}
+	chain, err := NewEntropyChain([]ChainSource{{Source: &biasedSource{}}, {Source: backup}})
+	is.NoError(err)
+
+	buf := make([]byte, aptWindow)
+	_, err = chain.Read(buf)
+	is.NoError(err)
+	is.Equal("backup", chain.Active())
+	is.ErrorIs(chain.Status()[0].LastError, ErrSourceHealthTestFailed)
+	is.Contains(chain.Status()[0].LastError.Error(), "adaptive proportion test")
+
+	m := &chainMember{rctCutoff: rctCutoffFor(fullEntropy), aptCutoff: 20}
+	_, _ = (&biasedSource{}).Read(buf)
+	is.NoError(m.healthTest(buf), "a cutoff of 20 does not detect the bias")
+}
+
+// blockingSource is a test EntropySource whose first read blocks until release is closed.
+type blockingSource struct {
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *blockingSource) Read(b []byte) (int, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+		<-s.release
+	}
+	return rand.Read(b)
+}
+
+func (s *blockingSource) Name() string { return "blocking" }
+
+// Test_EntropyChain_ConcurrentReads verifies that a read blocked in a source does not block other reads.
+func Test_EntropyChain_ConcurrentReads(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &blockingSource{started: make(chan struct{}), release: make(chan struct{})}
+	chain, err := NewEntropyChain([]ChainSource{{Source: src}})
+	is.NoError(err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := chain.Read(make([]byte, 48))
+		done <- err
+	}()
+	<-src.started
+
+	_, err = chain.Read(make([]byte, 48))
+	is.NoError(err, "a read should proceed while another is blocked in the source")
+	is.Equal("blocking", chain.Active())
+
+	close(src.release)
+	is.NoError(<-done)
+}
+
+// Test_EntropyChain_RetryPolicy verifies that transient errors are retried before failing over.
+func Test_EntropyChain_RetryPolicy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	primary.failNext.Store(2)
+	backup := &toggleSource{name: "backup"}
+	chain, err := NewEntropyChain([]ChainSource{
+		{Source: primary, Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}},
+		{Source: backup},
+	})
+	is.NoError(err)
+
+	buf := make([]byte, 48)
+	_, err = chain.Read(buf)
+	is.NoError(err)
+	is.Equal("primary", chain.Active())
+	is.Equal(int32(3), primary.reads.Load())
+	is.Equal(int32(0), backup.reads.Load())
+}
+
+// Test_EntropyChain_Exhausted verifies the error returned when every source fails.
+func Test_EntropyChain_Exhausted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := &toggleSource{name: "a"}
+	a.fail.Store(true)
+	b := &toggleSource{name: "b"}
+	b.fail.Store(true)
+	chain, err := NewEntropyChain([]ChainSource{{Source: a}, {Source: b}})
+	is.NoError(err)
+
+	buf := make([]byte, 48)
+	n, err := chain.Read(buf)
+	is.Equal(0, n)
+	is.ErrorIs(err, ErrEntropyChainExhausted)
+	is.ErrorIs(err, errToggleSource)
+}
+
+// Test_EntropyChain_Reader verifies that a Reader seeded from a chain survives primary failure.
+func Test_EntropyChain_Reader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	primary := &toggleSource{name: "primary"}
+	primary.fail.Store(true)
+	chain, err := NewEntropyChain([]ChainSource{{Source: primary}, {Source: systemSource{}}})
+	is.NoError(err)
+
+	rdr, err := NewReader(WithEntropySource(chain), WithEntropyTimeout(time.Second))
+	is.NoError(err)
+	is.NoError(rdr.Reseed(nil))
+
+	buf := make([]byte, 32)
+	_, err = rdr.Read(buf)
+	is.NoError(err)
+	is.Equal("crypto/rand", chain.Active())
+}
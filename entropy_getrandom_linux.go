@@ -0,0 +1,90 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build linux
+
+package ctrdrbg
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// sysGetrandom is the getrandom(2) syscall number for the running architecture.
+//
+// The frozen syscall package does not define SYS_GETRANDOM on every architecture,
+// so the numbers are listed here explicitly. Zero means unknown.
+var sysGetrandom = map[string]uintptr{
+	"386":      355,
+	"amd64":    318,
+	"arm":      384,
+	"arm64":    278,
+	"loong64":  278,
+	"mips":     4353,
+	"mipsle":   4353,
+	"mips64":   5313,
+	"mips64le": 5313,
+	"ppc64":    359,
+	"ppc64le":  359,
+	"riscv64":  278,
+	"s390x":    349,
+}[runtime.GOARCH]
+
+// getrandom invokes getrandom(2), retrying on EINTR.
+//
+// Returns ErrGetrandomUnsupported if the architecture is unknown or the kernel returns ENOSYS.
+func getrandom(b []byte, flags int) (int, error) {
+	if sysGetrandom == 0 {
+		return 0, ErrGetrandomUnsupported
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	for {
+		n, _, errno := syscall.Syscall(sysGetrandom, uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)), uintptr(flags))
+		switch errno {
+		case 0:
+			return int(n), nil
+		case syscall.EINTR:
+			continue
+		case syscall.ENOSYS:
+			return 0, ErrGetrandomUnsupported
+		default:
+			return 0, errno
+		}
+	}
+}
+
+// getrandomReady probes getrandom(2) with GRND_NONBLOCK alone.
+//
+// Readiness means that the CRNG is initialized, so the probe never uses GRND_RANDOM: before Linux 5.6 that
+// flag reads the blocking pool, which can report EAGAIN long after initialization, and each probe would
+// deplete its entropy estimate.
+//
+// Returns (false, nil) if the kernel reports EAGAIN (CRNG not yet initialized).
+func getrandomReady() (bool, error) {
+	var probe [1]byte
+	_, err := getrandom(probe[:], grndNonblock)
+	probe[0] = 0
+	if err == syscall.EAGAIN {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// systemEntropyReady reports whether the kernel CRNG backing crypto/rand is initialized.
+//
+// If getrandom(2) is unavailable, crypto/rand falls back to /dev/urandom and readiness is assumed.
+func systemEntropyReady() (bool, error) {
+	ready, err := getrandomReady()
+	if err == ErrGetrandomUnsupported {
+		return true, nil
+	}
+	return ready, err
+}
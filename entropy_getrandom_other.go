@@ -0,0 +1,23 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !linux
+
+package ctrdrbg
+
+// getrandom is unavailable outside Linux.
+func getrandom(_ []byte, _ int) (int, error) {
+	return 0, ErrGetrandomUnsupported
+}
+
+// getrandomReady is unavailable outside Linux.
+func getrandomReady() (bool, error) {
+	return false, ErrGetrandomUnsupported
+}
+
+// systemEntropyReady always reports true on platforms without a non-blocking entropy probe.
+func systemEntropyReady() (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultEntropyPrefetchMaxAge bounds the age of prefetched entropy when EntropyPrefetchMaxAge is zero.
+	defaultEntropyPrefetchMaxAge = time.Second
+
+	// maxEntropyPrefetchSize bounds the size of a single bulk entropy read.
+	maxEntropyPrefetchSize = 1 << 20
+)
+
+// entropyPrefetcher serves entropy input to the DRBG instances of one shard from a buffer filled by
+// bulk reads of the configured EntropySource, so prediction resistance costs one source read per
+// many reseeds instead of one per request.
+//
+// Each byte is served at most once and zeroized as soon as it is copied out. The buffer is discarded
+// (zeroized and refilled) when it cannot satisfy a request, when its contents are older than maxAge,
+// after a process fork, and after a VM generation change, so prefetched entropy is never shared
+// between a parent and child process or between VM clones.
+//
+// entropyPrefetcher is safe for concurrent use.
+type entropyPrefetcher struct {
+	src        EntropySource
+	maxAge     time.Duration
+	generation *generationWatcher
+
+	mu      sync.Mutex
+	buf     []byte
+	off     int
+	source  string
+	fetched time.Time
+	fork    uint64
+	epoch   uint64
+}
+
+// newEntropyPrefetcher returns an empty prefetcher that reads size bytes at a time from cfg's entropy source.
+func newEntropyPrefetcher(cfg *Config) *entropyPrefetcher {
+	maxAge := cfg.EntropyPrefetchMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultEntropyPrefetchMaxAge
+	}
+	p := &entropyPrefetcher{
+		src:        cfg.entropySource(),
+		maxAge:     maxAge,
+		generation: cfg.generation,
+		buf:        make([]byte, cfg.EntropyPrefetchSize),
+	}
+	p.off = len(p.buf)
+	return p
+}
+
+// Read fills b with prefetched entropy, refilling the buffer from the source if needed.
+// Requests larger than the buffer are read directly from the source.
+func (p *entropyPrefetcher) Read(b []byte) (int, error) {
+	if _, err := p.ReadSource(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// ReadSource implements SourceReporter: it reads as Read does and returns the name of the source that
+// supplied the buffered bytes it served.
+func (p *entropyPrefetcher) ReadSource(b []byte) (string, error) {
+	n := len(b)
+	if n > len(p.buf) {
+		return readSource(p.src, b)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.buf)-p.off < n || p.stale() {
+		clear(p.buf)
+		p.off = len(p.buf)
+		source, err := readSource(p.src, p.buf)
+		if err != nil {
+			clear(p.buf)
+			return "", err
+		}
+		p.off = 0
+		p.source = source
+		p.fetched = time.Now()
+		p.fork = processEpoch()
+		if p.generation != nil {
+			p.epoch = p.generation.current()
+		}
+	}
+
+	served := p.buf[p.off : p.off+n]
+	copy(b, served)
+	clear(served)
+	p.off += n
+	return p.source, nil
+}
+
+// stale reports whether the buffered entropy must be discarded: it is older than maxAge, or the process
+// has forked or the VM generation has changed since it was fetched. The caller must hold mu.
+func (p *entropyPrefetcher) stale() bool {
+	if time.Since(p.fetched) > p.maxAge || processEpoch() != p.fork {
+		return true
+	}
+	return p.generation != nil && p.generation.current() != p.epoch
+}
+
+// wipe zeroizes any unserved entropy and empties the buffer, so the next Read refills it.
+func (p *entropyPrefetcher) wipe() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.buf)
+	p.off = len(p.buf)
+}
+
+// Name returns the underlying source's name.
+func (p *entropyPrefetcher) Name() string {
+	return p.src.Name()
+}
+
+// validateEntropyPrefetch checks the EntropyPrefetchSize and EntropyPrefetchMaxAge settings.
+//
+// The prefetch buffer must hold at least one seed (KeySize + 16 bytes) and at most 1 MiB.
+func validateEntropyPrefetch(cfg *Config) error {
+	size := cfg.EntropyPrefetchSize
+	if size == 0 {
+		return nil
+	}
+	seedLen := int(cfg.KeySize) + 16
+	if size < seedLen || size > maxEntropyPrefetchSize {
+		return fmt.Errorf("%w: EntropyPrefetchSize %d: must be between %d and %d", ErrInvalidConfig, size, seedLen, maxEntropyPrefetchSize)
+	}
+	if cfg.EntropyPrefetchMaxAge < 0 {
+		return fmt.Errorf("%w: EntropyPrefetchMaxAge %s: must not be negative", ErrInvalidConfig, cfg.EntropyPrefetchMaxAge)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"crypto/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// callCountingSource is a test EntropySource that records how many reads were issued.
+type callCountingSource struct {
+	calls atomic.Int64
+}
+
+func (s *callCountingSource) Read(b []byte) (int, error) {
+	s.calls.Add(1)
+	return rand.Read(b)
+}
+
+func (s *callCountingSource) Name() string { return "call-counting" }
+
+// newPrefetchConfig returns a prediction-resistant config whose entropy is served through a prefetcher.
+func newPrefetchConfig(src EntropySource, size int, maxAge time.Duration) Config {
+	cfg := DefaultConfig()
+	cfg.PredictionResistance = true
+	cfg.EntropySource = src
+	cfg.EntropyPrefetchSize = size
+	cfg.EntropyPrefetchMaxAge = maxAge
+	cfg.prefetch = newEntropyPrefetcher(&cfg)
+	return cfg
+}
+
+// Test_EntropyPrefetch_Batches verifies that many prediction-resistance reseeds are served by one source read.
+func Test_EntropyPrefetch_Batches(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &callCountingSource{}
+	cfg := newPrefetchConfig(src, 48*64, time.Minute)
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	for i := 0; i < 32; i++ {
+		_, err = d.Read(buf)
+		is.NoError(err)
+	}
+	is.Equal(int64(1), src.calls.Load(), "instantiation and 32 reseeds should share one bulk read")
+
+	// Exhaust the remaining buffer; the next reseed must trigger a refill.
+	for i := 0; i < 31; i++ {
+		_, err = d.Read(buf)
+		is.NoError(err)
+	}
+	is.Equal(int64(1), src.calls.Load())
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Equal(int64(2), src.calls.Load(), "an exhausted buffer should be refilled")
+}
+
+// Test_EntropyPrefetch_WipesServedBytes verifies that served entropy is zeroized and never served twice.
+func Test_EntropyPrefetch_WipesServedBytes(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := newPrefetchConfig(&callCountingSource{}, 128, time.Minute)
+	p := cfg.prefetch
+
+	a := make([]byte, 48)
+	b := make([]byte, 48)
+	_, err := p.Read(a)
+	is.NoError(err)
+	_, err = p.Read(b)
+	is.NoError(err)
+	is.NotEqual(a, b, "each byte should be served at most once")
+	is.Equal(make([]byte, 96), p.buf[:96], "served bytes should be zeroized")
+	is.Equal(96, p.off)
+}
+
+// Test_EntropyPrefetch_Refill verifies that stale buffers are discarded after expiry, fork, and
+// generation change, and that oversized requests bypass the buffer.
+func Test_EntropyPrefetch_Refill(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		maxAge  time.Duration
+		prepare func(p *entropyPrefetcher, fake *FakeGenerationIDProvider)
+	}{
+		{"Expired", time.Millisecond, func(*entropyPrefetcher, *FakeGenerationIDProvider) { time.Sleep(5 * time.Millisecond) }},
+		{"Fork", time.Minute, func(p *entropyPrefetcher, _ *FakeGenerationIDProvider) { p.fork-- }},
+		{"Generation", time.Minute, func(_ *entropyPrefetcher, fake *FakeGenerationIDProvider) { fake.Advance() }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			fake := NewFakeGenerationIDProvider()
+			w, err := newGenerationWatcher(fake, 0)
+			is.NoError(err)
+
+			src := &callCountingSource{}
+			cfg := DefaultConfig()
+			cfg.EntropySource = src
+			cfg.EntropyPrefetchSize = 1024
+			cfg.EntropyPrefetchMaxAge = tc.maxAge
+			cfg.generation = w
+			p := newEntropyPrefetcher(&cfg)
+
+			buf := make([]byte, 48)
+			_, err = p.Read(buf)
+			is.NoError(err)
+			is.Equal(int64(1), src.calls.Load())
+
+			tc.prepare(p, fake)
+			_, err = p.Read(buf)
+			is.NoError(err)
+			is.Equal(int64(2), src.calls.Load(), "stale buffer should be refilled")
+			is.Equal(48, p.off, "refill should discard the remaining stale bytes")
+		})
+	}
+
+	t.Run("Oversized", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		src := &callCountingSource{}
+		cfg := DefaultConfig()
+		cfg.EntropySource = src
+		cfg.EntropyPrefetchSize = 64
+		p := newEntropyPrefetcher(&cfg)
+
+		_, err := p.Read(make([]byte, 128))
+		is.NoError(err)
+		is.Equal(int64(1), src.calls.Load())
+		is.Equal(len(p.buf), p.off, "oversized reads should not fill the buffer")
+	})
+}
+
+// Test_EntropyPrefetch_Reader verifies that a prediction-resistant Reader works with prefetching and that
+// the per-shard prefetcher is not exposed through Config.
+func Test_EntropyPrefetch_Reader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &callCountingSource{}
+	r, err := NewReader(
+		WithEntropySource(src),
+		WithPredictionResistance(true),
+		WithShards(1),
+		WithEntropyPrefetch(4096, time.Minute),
+	)
+	is.NoError(err)
+
+	buf := make([]byte, 64)
+	for i := 0; i < 16; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+	is.Less(src.calls.Load(), int64(16), "reseeds should be batched")
+
+	cfg := r.Config()
+	is.Equal(4096, cfg.EntropyPrefetchSize)
+	is.Equal(time.Minute, cfg.EntropyPrefetchMaxAge)
+	is.Nil(cfg.prefetch)
+}
+
+// Test_EntropyPrefetch_Validate verifies the EntropyPrefetchSize and EntropyPrefetchMaxAge checks.
+func Test_EntropyPrefetch_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		opts   []Option
+		hasErr bool
+	}{
+		{"Disabled", nil, false},
+		{"MinimumSize", []Option{WithEntropyPrefetch(48, 0)}, false},
+		{"TooSmall", []Option{WithEntropyPrefetch(47, 0)}, true},
+		{"MinimumSizeAES128", []Option{WithKeySize(KeySize128), WithEntropyPrefetch(32, 0)}, false},
+		{"TooLarge", []Option{WithEntropyPrefetch(maxEntropyPrefetchSize+1, 0)}, true},
+		{"NegativeMaxAge", []Option{WithEntropyPrefetch(4096, -time.Second)}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			_, err := NewReader(tc.opts...)
+			if tc.hasErr {
+				is.Error(err)
+			} else {
+				is.NoError(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSource is a test EntropySource that records how many bytes were requested.
+type countingSource struct {
+	bytes atomic.Uint64
+}
+
+func (s *countingSource) Read(b []byte) (int, error) {
+	s.bytes.Add(uint64(len(b)))
+	return rand.Read(b)
+}
+
+func (s *countingSource) Name() string { return "counting" }
+
+// stalledSource is a test EntropySource whose readiness probe never succeeds.
+type stalledSource struct{}
+
+func (stalledSource) Read(b []byte) (int, error) { return rand.Read(b) }
+func (stalledSource) Name() string               { return "stalled" }
+func (stalledSource) Ready() (bool, error)       { return false, nil }
+
+// failingSource is a test EntropySource that always returns an error.
+type failingSource struct{}
+
+var errFailingSource = errors.New("failing source")
+
+func (failingSource) Read(_ []byte) (int, error) { return 0, errFailingSource }
+func (failingSource) Name() string               { return "failing" }
+
+// Test_EntropySource_Default verifies that the default source is crypto/rand and is ready.
+func Test_EntropySource_Default(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	src := cfg.entropySource()
+	is.Equal("crypto/rand", src.Name())
+
+	rc, ok := src.(ReadinessChecker)
+	is.True(ok, "default source should implement ReadinessChecker")
+	ready, err := rc.Ready()
+	is.NoError(err)
+	is.True(ready, "system entropy should be ready in a running test process")
+}
+
+// Test_EntropySource_Custom verifies that instantiation and reseed draw from the configured source.
+func Test_EntropySource_Custom(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &countingSource{}
+	rdr, err := NewReader(WithEntropySource(src), WithShards(1))
+	is.NoError(err)
+
+	seedLen := uint64(KeySize256 + 16)
+	is.GreaterOrEqual(src.bytes.Load(), seedLen, "instantiation should consume one seed from the source")
+
+	is.NoError(rdr.Reseed(nil))
+
+	// Count reseed consumption on a single instance: a sync.Pool may construct additional
+	// instances at any time (and does so randomly under the race detector).
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	before := src.bytes.Load()
+	is.NoError(d.Reseed(nil))
+	is.Equal(before+seedLen, src.bytes.Load(), "reseed should consume one seed from the source")
+}
+
+// Test_EntropySource_Failure verifies that a failing source surfaces an error from NewReader.
+func Test_EntropySource_Failure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithEntropySource(failingSource{}), WithShards(1))
+	is.Error(err)
+	is.Nil(rdr)
+	is.ErrorIs(err, errFailingSource)
+}
+
+// Test_EntropyTimeout_NotReady verifies that NewReader returns a typed error instead of hanging.
+func Test_EntropyTimeout_NotReady(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	start := time.Now()
+	rdr, err := NewReader(WithEntropySource(stalledSource{}), WithEntropyTimeout(20*time.Millisecond))
+	is.Nil(rdr)
+	is.ErrorIs(err, ErrEntropyNotReady)
+	is.Less(time.Since(start), 2*time.Second, "NewReader should not hang past the timeout")
+
+	var nre *EntropyNotReadyError
+	is.True(errors.As(err, &nre))
+	is.Equal("stalled", nre.Source)
+	is.Equal(20*time.Millisecond, nre.Timeout)
+}
+
+// Test_EntropyTimeout_Disabled verifies that a zero timeout skips the readiness probe.
+func Test_EntropyTimeout_Disabled(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithEntropySource(stalledSource{}))
+	is.NoError(err)
+	is.NotNil(rdr)
+}
+
+// Test_GetrandomSource verifies that the getrandom(2) source is ready and produces output on Linux.
+func Test_GetrandomSource(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	for _, randomPool := range []bool{false, true} {
+		src, err := NewGetrandomSource(randomPool)
+		if runtime.GOOS != "linux" {
+			is.ErrorIs(err, ErrGetrandomUnsupported)
+			return
+		}
+		is.NoError(err)
+
+		ready, err := src.Ready()
+		is.NoError(err)
+		is.True(ready)
+
+		buf1 := make([]byte, 48)
+		buf2 := make([]byte, 48)
+		_, err = src.Read(buf1)
+		is.NoError(err)
+		_, err = src.Read(buf2)
+		is.NoError(err)
+		is.False(bytes.Equal(buf1, buf2))
+	}
+}
+
+// Test_GetrandomSource_Reader verifies that a Reader seeded from getrandom(2) produces output.
+func Test_GetrandomSource_Reader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src, err := NewGetrandomSource(false)
+	if err != nil {
+		t.Skipf("getrandom unavailable: %v", err)
+	}
+
+	rdr, err := NewReader(WithEntropySource(src), WithEntropyTimeout(time.Second))
+	is.NoError(err)
+
+	buf := make([]byte, 64)
+	n, err := rdr.Read(buf)
+	is.NoError(err)
+	is.Equal(64, n)
+	is.Equal("getrandom", src.Name())
+}
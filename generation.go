@@ -0,0 +1,190 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrGenerationIDUnavailable is returned by a GenerationIDProvider when no generation ID is exposed
+	// on this platform (for example, on bare metal or when the hypervisor does not provide vmgenid).
+	ErrGenerationIDUnavailable = errors.New("ctrdrbg: VM generation ID unavailable")
+)
+
+// GenerationIDProvider reports an identifier that changes whenever the execution environment is cloned
+// or restored from a snapshot, such as the ACPI Virtual Machine Generation ID (vmgenid) or a counter
+// bumped by a checkpoint/restore tool (e.g., CRIU).
+//
+// A restored snapshot keeps the same process ID and replays identical DRBG state, so PID-based fork
+// detection cannot notice it. When the generation ID changes, every DRBG instance of the Reader is
+// reseeded before it produces further output.
+//
+// Implementations must be safe for concurrent use.
+type GenerationIDProvider interface {
+	// GenerationID returns the current generation ID. The value is opaque and compared byte-wise.
+	GenerationID() ([]byte, error)
+}
+
+// SysfsGenerationIDProvider is a GenerationIDProvider that reads the generation ID from a file. It is
+// supported only on Linux.
+//
+// It is opt-in: mainline Linux does not expose the ACPI vmgenid value to user space (the vmgenid driver
+// reseeds the kernel RNG and emits a uevent instead), so there is no default path. Path must name a file
+// published on the target platform, for example by a guest agent, a udev rule reacting to the vmgenid
+// uevent, or a checkpoint/restore hook that bumps a counter. Leading and trailing whitespace is ignored.
+type SysfsGenerationIDProvider struct {
+	// Path is the file containing the generation ID.
+	Path string
+}
+
+// NewSysfsGenerationIDProvider returns a SysfsGenerationIDProvider reading path, after verifying that a
+// generation ID can be read from it.
+//
+// Returns an error wrapping ErrGenerationIDUnavailable if path is empty, the file does not exist, or the
+// platform is not Linux.
+func NewSysfsGenerationIDProvider(path string) (*SysfsGenerationIDProvider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: no generation ID path given", ErrGenerationIDUnavailable)
+	}
+	p := &SysfsGenerationIDProvider{Path: path}
+	if _, err := p.GenerationID(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GenerationID reads and returns the generation ID from Path.
+func (p *SysfsGenerationIDProvider) GenerationID() ([]byte, error) {
+	return readGenerationID(p.Path)
+}
+
+// FakeGenerationIDProvider is a GenerationIDProvider whose generation ID is set by the caller.
+//
+// It is intended for tests that simulate a VM snapshot restore or clone by calling Advance.
+// The zero value is ready to use and reports generation 0.
+type FakeGenerationIDProvider struct {
+	gen atomic.Uint64
+	err atomic.Pointer[error]
+}
+
+// NewFakeGenerationIDProvider returns a FakeGenerationIDProvider at generation 0.
+func NewFakeGenerationIDProvider() *FakeGenerationIDProvider {
+	return &FakeGenerationIDProvider{}
+}
+
+// GenerationID returns the current generation, encoded as 8 big-endian bytes, or the error set by SetError.
+func (p *FakeGenerationIDProvider) GenerationID() ([]byte, error) {
+	if err := p.err.Load(); err != nil {
+		return nil, *err
+	}
+	g := p.gen.Load()
+	return []byte{byte(g >> 56), byte(g >> 48), byte(g >> 40), byte(g >> 32), byte(g >> 24), byte(g >> 16), byte(g >> 8), byte(g)}, nil
+}
+
+// Advance simulates a snapshot restore or clone by changing the generation ID.
+func (p *FakeGenerationIDProvider) Advance() {
+	p.gen.Add(1)
+}
+
+// SetError makes subsequent GenerationID calls return err. Passing nil clears the error.
+func (p *FakeGenerationIDProvider) SetError(err error) {
+	if err == nil {
+		p.err.Store(nil)
+		return
+	}
+	p.err.Store(&err)
+}
+
+// generationWatcher tracks the generation ID on behalf of every DRBG instance of a Reader.
+//
+// The provider is consulted at most once per interval. Each observed change (or read failure) advances
+// epoch; instances compare their cached epoch against it and reseed when it differs.
+type generationWatcher struct {
+	provider GenerationIDProvider
+	interval time.Duration
+
+	// epoch is incremented each time a generation change is observed.
+	epoch atomic.Uint64
+
+	// nextCheck is the earliest time (UnixNano) at which the provider is consulted again.
+	nextCheck atomic.Int64
+
+	mu   sync.Mutex
+	last []byte
+}
+
+// newGenerationWatcher reads the initial generation ID from provider.
+//
+// Returns an error if the provider cannot report a generation ID.
+func newGenerationWatcher(provider GenerationIDProvider, interval time.Duration) (*generationWatcher, error) {
+	id, err := provider.GenerationID()
+	if err != nil {
+		return nil, fmt.Errorf("ctrdrbg: reading initial generation ID: %w", err)
+	}
+	w := &generationWatcher{provider: provider, interval: interval, last: bytes.Clone(id)}
+	w.nextCheck.Store(time.Now().Add(interval).UnixNano())
+	return w, nil
+}
+
+// current returns the epoch, first consulting the provider if the check interval has elapsed.
+//
+// A provider error is treated as a generation change, so instances reseed rather than risk
+// replaying state when the generation cannot be determined.
+func (w *generationWatcher) current() uint64 {
+	if w.interval > 0 && time.Now().UnixNano() < w.nextCheck.Load() {
+		return w.epoch.Load()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Another caller may have performed the check while this one waited for the lock.
+	now := time.Now()
+	if w.interval > 0 && now.UnixNano() < w.nextCheck.Load() {
+		return w.epoch.Load()
+	}
+
+	id, err := w.provider.GenerationID()
+	switch {
+	case err != nil:
+		w.epoch.Add(1)
+	case !bytes.Equal(id, w.last):
+		w.last = append(w.last[:0], id...)
+		w.epoch.Add(1)
+	}
+	w.nextCheck.Store(now.Add(w.interval).UnixNano())
+	return w.epoch.Load()
+}
+
+// reseedIfGenerationChanged reseeds the instance if the VM generation ID changed since its last check.
+//
+// This detects VM snapshot restores and clones, which preserve the process ID and are therefore
+// invisible to reseedIfForked. It is a no-op unless a GenerationIDProvider is configured.
+//
+// If the reseed fails, the instance still holds the state it had at the snapshot, so the error is returned
+// and no output may be generated. The change remains pending, and the next request retries the reseed.
+func (d *drbg) reseedIfGenerationChanged() error {
+	w := d.config.generation
+	if w == nil {
+		return nil
+	}
+	if epoch := w.current(); epoch != d.genEpoch {
+		d.logDetected(ReseedCauseGeneration)
+		err := d.reseed(ReseedCauseGeneration, nil) // Re-instantiation for RBG1
+		d.forkEvent(ReseedCauseGeneration, err)
+		if err != nil {
+			return fmt.Errorf("generation change reseed failed: %w", err)
+		}
+		d.genEpoch = epoch
+	}
+	return nil
+}
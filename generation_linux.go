@@ -0,0 +1,32 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build linux
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// readGenerationID reads the generation ID from path, trimming surrounding whitespace.
+func readGenerationID(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrGenerationIDUnavailable, path)
+		}
+		return nil, err
+	}
+	id := bytes.TrimSpace(b)
+	if len(id) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrGenerationIDUnavailable, path)
+	}
+	return id, nil
+}
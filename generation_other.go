@@ -0,0 +1,13 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !linux
+
+package ctrdrbg
+
+// readGenerationID is unavailable outside Linux.
+func readGenerationID(_ string) ([]byte, error) {
+	return nil, ErrGenerationIDUnavailable
+}
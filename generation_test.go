@@ -0,0 +1,192 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GenerationID_Reseed verifies that an instance reseeds exactly once per generation change.
+func Test_GenerationID_Reseed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	fake := NewFakeGenerationIDProvider()
+	cfg := DefaultConfig()
+	w, err := newGenerationWatcher(fake, 0)
+	is.NoError(err)
+	cfg.generation = w
+
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	buf := make([]byte, 32)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	key := d.state.key
+
+	// Simulate a snapshot restore: same PID, new generation.
+	fake.Advance()
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.NotEqual(key, d.state.key, "generation change should reseed the instance")
+
+	key = d.state.key
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Equal(key, d.state.key, "unchanged generation should not reseed")
+}
+
+// Test_GenerationID_ReseedFailure verifies that an instance whose reseed fails after a generation change
+// returns an error instead of output from the cloned state, and retries the reseed on the next request.
+func Test_GenerationID_ReseedFailure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	fake := NewFakeGenerationIDProvider()
+	src := &toggleSource{name: "toggle"}
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	w, err := newGenerationWatcher(fake, 0)
+	is.NoError(err)
+	cfg.generation = w
+
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+	key := d.state.key
+
+	fake.Advance()
+	src.fail.Store(true)
+	buf := make([]byte, 32)
+	for i := 0; i < 2; i++ {
+		n, err := d.Read(buf)
+		is.ErrorIs(err, errToggleSource)
+		is.Zero(n)
+		_, err = d.ReadWithAdditionalInput(buf, []byte("input"))
+		is.ErrorIs(err, errToggleSource)
+	}
+	is.Equal(key, d.state.key, "a failed reseed must leave the state unchanged")
+	is.Equal(make([]byte, len(buf)), buf, "no output may be generated from the cloned state")
+
+	src.fail.Store(false)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.NotEqual(key, d.state.key, "the pending generation change should reseed once the source recovers")
+	is.Equal(w.current(), d.genEpoch)
+}
+
+// Test_GenerationID_Reader verifies that a Reader's instances reseed once after a generation change and
+// not otherwise.
+//
+// The assertions use one instance taken from the Reader's pool, since the pool may construct additional
+// instances at any time (and does so at random under the race detector), each drawing its own seed.
+func Test_GenerationID_Reader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	fake := NewFakeGenerationIDProvider()
+	src := &countingSource{}
+	rdr, err := NewReader(WithEntropySource(src), WithGenerationIDProvider(fake), WithGenerationIDCheckInterval(0),
+		WithShards(1))
+	is.NoError(err)
+	is.Nil(rdr.Config().generation, "runtime watcher must not leak through Config")
+
+	pool := rdr.(*reader).pools[0]
+	d, err := pool.get()
+	is.NoError(err)
+	defer pool.put(d)
+
+	buf := make([]byte, 32)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	before := src.bytes.Load()
+
+	fake.Advance()
+	_, err = d.Read(buf)
+	is.NoError(err)
+	seedLen := uint64(KeySize256 + 16)
+	is.Equal(before+seedLen, src.bytes.Load(), "read after generation change should reseed once")
+
+	before = src.bytes.Load()
+	_, err = d.Read(buf)
+	is.NoError(err)
+	_, err = d.ReadWithAdditionalInput(buf, nil)
+	is.NoError(err)
+	is.Equal(before, src.bytes.Load(), "no second reseed without a generation change")
+}
+
+// Test_GenerationID_Interval verifies that the provider is consulted at most once per check interval.
+func Test_GenerationID_Interval(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	fake := NewFakeGenerationIDProvider()
+	w, err := newGenerationWatcher(fake, time.Hour)
+	is.NoError(err)
+
+	epoch := w.current()
+	fake.Advance()
+	is.Equal(epoch, w.current(), "change should not be observed before the interval elapses")
+
+	w.nextCheck.Store(0)
+	is.Equal(epoch+1, w.current(), "change should be observed once the interval elapses")
+}
+
+// Test_GenerationID_Errors verifies provider error handling at instantiation and at runtime.
+func Test_GenerationID_Errors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	fake := NewFakeGenerationIDProvider()
+	fake.SetError(ErrGenerationIDUnavailable)
+	_, err := NewReader(WithGenerationIDProvider(fake))
+	is.ErrorIs(err, ErrGenerationIDUnavailable)
+
+	// A runtime failure is treated as a generation change.
+	fake.SetError(nil)
+	w, err := newGenerationWatcher(fake, 0)
+	is.NoError(err)
+	epoch := w.current()
+	fake.SetError(errors.New("probe failed"))
+	is.Equal(epoch+1, w.current())
+}
+
+// Test_SysfsGenerationIDProvider verifies reading a generation ID from a file.
+func Test_SysfsGenerationIDProvider(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	dir := t.TempDir()
+	_, err := NewSysfsGenerationIDProvider(filepath.Join(dir, "missing"))
+	is.ErrorIs(err, ErrGenerationIDUnavailable)
+	_, err = NewSysfsGenerationIDProvider("")
+	is.ErrorIs(err, ErrGenerationIDUnavailable, "there is no default generation ID path")
+
+	if runtime.GOOS != "linux" {
+		t.Skip("sysfs generation ID is only supported on Linux")
+	}
+
+	path := filepath.Join(dir, "generation_id")
+	is.NoError(os.WriteFile(path, []byte("0123456789abcdef\n"), 0o600))
+	p, err := NewSysfsGenerationIDProvider(path)
+	is.NoError(err)
+
+	id, err := p.GenerationID()
+	is.NoError(err)
+	is.Equal([]byte("0123456789abcdef"), id)
+
+	is.NoError(os.WriteFile(path, []byte("fedcba9876543210\n"), 0o600))
+	id, err = p.GenerationID()
+	is.NoError(err)
+	is.Equal([]byte("fedcba9876543210"), id)
+}
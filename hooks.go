@@ -0,0 +1,165 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"time"
+)
+
+// Hooks holds optional callbacks for DRBG lifecycle events, installed with WithHooks. Any field may be nil.
+//
+// Hooks run synchronously on the goroutine that owns the instance, which is the goroutine calling Read,
+// ReadWithAdditionalInput, Reseed, or a Session method. OnSelfTest runs on the goroutine calling NewReader.
+// No lock is held while a hook runs, and the event has taken effect (for example, the new seed is
+// installed) before its hook is called, so a hook may use any Reader, including the one that fired it.
+// Hooks delay the request that fired them, so they should return quickly, handing slow work (such as
+// paging) to another goroutine. A hook is never passed secret material.
+type Hooks struct {
+	// OnReseed is called after every reseed attempt, successful or not.
+	OnReseed func(ReseedEvent)
+
+	// OnRekey is called after a key rotation installs a new key. In RekeyModeAsync, the new seed is prepared
+	// in the background and the hook fires when the owner installs it at its next request.
+	OnRekey func(RekeyEvent)
+
+	// OnRekeyFailure is called when a key rotation exhausts MaxRekeyAttempts. It complements
+	// RekeyFailureHandler, which receives the same *RekeyError.
+	OnRekeyFailure func(RekeyFailureEvent)
+
+	// OnFork is called after an instance detects that its state may have been duplicated, by a process fork
+	// or a VM generation change, and has reseeded. Output generated before the event may have been
+	// duplicated in another process, so this is the place to invalidate caches derived from it.
+	OnFork func(ForkEvent)
+
+	// OnHealthFailure is called when the continuous health test fails. The failing request returns
+	// ErrHealthTestFailed.
+	OnHealthFailure func(HealthFailureEvent)
+
+	// OnSelfTest is called by NewReader with the result of the Known Answer Tests, when EnableSelfTests is set.
+	OnSelfTest func(SelfTestEvent)
+}
+
+// ReseedEvent describes a reseed, reported to Hooks.OnReseed.
+type ReseedEvent struct {
+	// Shard is the index of the instance's shard.
+	Shard int
+
+	// Cause is the reason for the reseed.
+	Cause ReseedCause
+
+	// Duration is the time taken to derive and install the new seed.
+	Duration time.Duration
+
+	// Err is nil on success. On failure, the instance retains its previous state.
+	Err error
+}
+
+// RekeyEvent describes a successful key rotation, reported to Hooks.OnRekey.
+type RekeyEvent struct {
+	// Shard is the index of the instance's shard.
+	Shard int
+
+	// Mode is the RekeyMode in effect.
+	Mode RekeyMode
+
+	// Duration is the seed derivation time, including any retries and backoff.
+	Duration time.Duration
+}
+
+// RekeyFailureEvent describes a key rotation that exhausted its attempts, reported to Hooks.OnRekeyFailure.
+type RekeyFailureEvent struct {
+	// Shard is the index of the instance's shard.
+	Shard int
+
+	// Err describes the failure; it matches ErrRekeyFailed.
+	Err *RekeyError
+}
+
+// ForkEvent describes a detected process fork or VM generation change, reported to Hooks.OnFork.
+type ForkEvent struct {
+	// Shard is the index of the instance's shard.
+	Shard int
+
+	// Cause is ReseedCauseFork for a process fork, or ReseedCauseGeneration for a VM generation change.
+	Cause ReseedCause
+
+	// Err is the error of the reseed that followed detection, or nil if it succeeded.
+	Err error
+}
+
+// HealthFailureEvent describes a continuous health test failure, reported to Hooks.OnHealthFailure.
+type HealthFailureEvent struct {
+	// Shard is the index of the instance's shard.
+	Shard int
+}
+
+// SelfTestEvent describes the result of the Known Answer Tests, reported to Hooks.OnSelfTest.
+type SelfTestEvent struct {
+	// Duration is the time taken by RunSelfTests.
+	Duration time.Duration
+
+	// Err is nil if the tests passed, or an error matching ErrSelfTestFailed.
+	Err error
+}
+
+// set reports whether any callback is installed.
+func (h *Hooks) set() bool {
+	return h.OnReseed != nil || h.OnRekey != nil || h.OnRekeyFailure != nil || h.OnFork != nil ||
+		h.OnHealthFailure != nil || h.OnSelfTest != nil
+}
+
+// The functions below report each lifecycle event to the configured Logger, AuditLog, and Hooks.
+
+// reseedEvent reports a reseed attempt.
+func (d *drbg) reseedEvent(cause ReseedCause, elapsed time.Duration, err error) {
+	d.logReseed(cause, elapsed, err)
+	if err == nil {
+		d.auditEvent(AuditReseed, cause.String())
+	}
+	if h := d.config.Hooks.OnReseed; h != nil {
+		h(ReseedEvent{Shard: d.config.shard, Cause: cause, Duration: elapsed, Err: err})
+	}
+}
+
+// rekeyEvent reports a successful key rotation.
+func (d *drbg) rekeyEvent(elapsed time.Duration) {
+	d.logRekey(elapsed)
+	d.auditEvent(AuditRekey, "")
+	if h := d.config.Hooks.OnRekey; h != nil {
+		h(RekeyEvent{Shard: d.config.shard, Mode: d.config.RekeyMode, Duration: elapsed})
+	}
+}
+
+// rekeyFailureEvent reports a key rotation that exhausted its attempts.
+func (d *drbg) rekeyFailureEvent(re *RekeyError) {
+	d.logRekeyFailed(re)
+	if h := d.config.Hooks.OnRekeyFailure; h != nil {
+		h(RekeyFailureEvent{Shard: d.config.shard, Err: re})
+	}
+}
+
+// forkEvent reports a detected fork or VM generation change, after the instance has reseeded.
+func (d *drbg) forkEvent(cause ReseedCause, err error) {
+	if h := d.config.Hooks.OnFork; h != nil {
+		h(ForkEvent{Shard: d.config.shard, Cause: cause, Err: err})
+	}
+}
+
+// healthFailureEvent reports a continuous health test failure.
+func (d *drbg) healthFailureEvent() {
+	d.logHealthTestFailed()
+	if h := d.config.Hooks.OnHealthFailure; h != nil {
+		h(HealthFailureEvent{Shard: d.config.shard})
+	}
+}
+
+// selfTestEvent reports the result of the Known Answer Tests run by NewReader.
+func selfTestEvent(cfg *Config, elapsed time.Duration, err error) {
+	logSelfTests(cfg, elapsed, err)
+	if h := cfg.Hooks.OnSelfTest; h != nil {
+		h(SelfTestEvent{Duration: elapsed, Err: err})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventRecorder collects the events passed to a hook, which may be called from any goroutine.
+type eventRecorder[E any] struct {
+	mu     sync.Mutex
+	events []E
+}
+
+func (r *eventRecorder[E]) record(e E) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder[E]) all() []E {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]E(nil), r.events...)
+}
+
+// Test_WithHooks verifies the self-test, reseed, and fork hooks of a Reader.
+func Test_WithHooks(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var (
+		selfTests eventRecorder[SelfTestEvent]
+		reseeds   eventRecorder[ReseedEvent]
+		forks     eventRecorder[ForkEvent]
+		r         Interface
+	)
+	fake := NewFakeGenerationIDProvider()
+	hooks := Hooks{
+		OnSelfTest: selfTests.record,
+		OnReseed:   reseeds.record,
+		OnFork: func(e ForkEvent) {
+			forks.record(e)
+			// A hook may use the Reader that fired it.
+			_, err := r.Read(make([]byte, 16))
+			is.NoError(err)
+		},
+	}
+
+	var err error
+	r, err = NewReader(WithShards(1), WithHooks(hooks), WithSelfTests(true), WithReseedRequests(1),
+		WithGenerationIDProvider(fake), WithGenerationIDCheckInterval(0))
+	is.NoError(err)
+	defer r.Close()
+
+	is.Len(selfTests.all(), 1)
+	is.NoError(selfTests.all()[0].Err)
+
+	buf := make([]byte, 32)
+	for i := 0; i < 2; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+	events := reseeds.all()
+	is.Len(events, 1)
+	is.Equal(ReseedCauseRequestCount, events[0].Cause)
+	is.Zero(events[0].Shard)
+	is.NoError(events[0].Err)
+	is.Positive(events[0].Duration)
+
+	// Simulate a fork, then a VM generation change.
+	s, err := r.Acquire()
+	is.NoError(err)
+	s.d.forkEpoch--
+	_, err = s.Read(buf)
+	is.NoError(err)
+	s.Release()
+	fake.Advance()
+	_, err = r.Read(buf)
+	is.NoError(err)
+
+	// Every instance reports the generation change, including the one created by the hook's own read.
+	is.GreaterOrEqual(len(forks.all()), 2)
+	is.Equal(ForkEvent{Cause: ReseedCauseFork}, forks.all()[0])
+	for _, e := range forks.all()[1:] {
+		is.Equal(ForkEvent{Cause: ReseedCauseGeneration}, e)
+	}
+	var causes []ReseedCause
+	for _, e := range reseeds.all() {
+		causes = append(causes, e.Cause)
+	}
+	is.Contains(causes, ReseedCauseFork)
+	is.Contains(causes, ReseedCauseGeneration)
+}
+
+// Test_WithHooks_Failures verifies the rekey, rekey failure, failed reseed, and health failure hooks.
+func Test_WithHooks_Failures(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var (
+		rekeys        eventRecorder[RekeyEvent]
+		rekeyFailures eventRecorder[RekeyFailureEvent]
+		reseeds       eventRecorder[ReseedEvent]
+		health        eventRecorder[HealthFailureEvent]
+	)
+	d, src, _ := newFailingRekeyDRBG(t, RekeyModeSync, RekeyFailureContinue)
+	d.config.Hooks = Hooks{
+		OnRekey:         rekeys.record,
+		OnRekeyFailure:  rekeyFailures.record,
+		OnReseed:        reseeds.record,
+		OnHealthFailure: health.record,
+	}
+
+	buf := make([]byte, 64)
+	_, err := d.Read(buf)
+	is.NoError(err)
+	is.Len(rekeyFailures.all(), 1)
+	is.ErrorIs(rekeyFailures.all()[0].Err, ErrRekeyFailed)
+	is.ErrorIs(rekeyFailures.all()[0].Err, errToggleSource)
+
+	is.Error(d.Reseed(nil))
+	is.Len(reseeds.all(), 1)
+	is.Equal(ReseedCauseManual, reseeds.all()[0].Cause)
+	is.ErrorIs(reseeds.all()[0].Err, errToggleSource)
+
+	src.fail.Store(false)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Len(rekeys.all(), 1)
+	is.Equal(RekeyModeSync, rekeys.all()[0].Mode)
+
+	block := make([]byte, 16)
+	is.NoError(d.continuousHealthTest(block))
+	is.ErrorIs(d.continuousHealthTest(block), ErrHealthTestFailed)
+	is.Len(health.all(), 1)
+}
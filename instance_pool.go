@@ -0,0 +1,311 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInstantiationFailed is the sentinel matched (via errors.Is) by *InstantiationError.
+var ErrInstantiationFailed = errors.New("ctrdrbg: instantiation failed")
+
+// InstantiationError reports that a DRBG instance could not be instantiated after MaxInitRetries attempts,
+// typically because the entropy source failed.
+//
+// It is returned by NewReader, and by Read, ReadWithAdditionalInput, Reseed, and Acquire when a shard needs
+// a new instance. It matches ErrInstantiationFailed and the underlying cause via errors.Is.
+type InstantiationError struct {
+	// Attempts is the number of instantiation attempts made (MaxInitRetries).
+	Attempts int
+
+	// Err is the failure of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *InstantiationError) Error() string {
+	return fmt.Sprintf("ctrdrbg: instantiation failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns ErrInstantiationFailed and the underlying cause so callers can match either with errors.Is.
+func (e *InstantiationError) Unwrap() []error {
+	return []error{ErrInstantiationFailed, e.Err}
+}
+
+// Instance claim states, stored in drbg.claim.
+const (
+	// instanceIdle indicates the instance is available to be taken from its pool.
+	instanceIdle uint32 = iota
+
+	// instanceBusy indicates the instance is in use by exactly one caller.
+	instanceBusy
+
+	// instanceClosed indicates the instance has been uninstantiated by Close.
+	instanceClosed
+)
+
+// instancePool is the pool of DRBG instances for one shard of a Reader.
+//
+// Idle instances are cached in a sync.Pool for speed. Because a sync.Pool may drop its contents at any
+// garbage collection, and cannot be enumerated, the pool also registers every instance it creates in live.
+// An instance is owned by whoever moves its claim from instanceIdle to instanceBusy, so an instance dropped
+// by the sync.Pool is reused from live instead of being lost, and Close can uninstantiate every idle
+// instance without racing its users.
+//
+// The registry is bounded by maxLive: an instance returned while more are registered, left over from a
+// burst of concurrent use, is uninstantiated and unregistered instead of cached, and its counters are
+// folded into retired so Stats still reports them. Close unregisters instances the same way.
+//
+// Reseed advances the pool's epoch; every instance compares its cached epoch when it is taken from the
+// pool (or read through a Session) and reseeds before generating output if it differs. This is the same
+// approach used for VM generation changes (see generationWatcher), and it guarantees that no instance,
+// idle or in use, generates output from its pre-Reseed state once Reseed has returned.
+type instancePool struct {
+	// cfg is shared by every instance in the shard.
+	cfg *Config
+
+	// pool caches idle instances.
+	pool sync.Pool
+
+	// epoch is advanced by each Reseed; see refresh.
+	epoch atomic.Uint64
+
+	// closed is set by Close.
+	closed atomic.Bool
+
+	// created counts the instances constructed by the pool, for Stats.
+	created atomic.Uint64
+
+	// maxLive is the number of registered instances above which put retires instances.
+	maxLive int
+
+	// live holds the instances registered by the pool. The slice is replaced, never modified, under mu,
+	// so getSlow can scan it without holding the lock.
+	live atomic.Pointer[[]*drbg]
+
+	// mu guards reseedInput and retired, and serializes updates to live.
+	mu sync.Mutex
+
+	// reseedInput is the additional input of the most recent Reseed, applied by refresh.
+	reseedInput []byte
+
+	// retired holds the counters of instances unregistered from live.
+	retired ShardStats
+}
+
+// minMaxLive is the lower bound of instancePool.maxLive.
+const minMaxLive = 8
+
+// newInstancePool returns an empty pool whose instances are constructed from cfg.
+//
+// The pool keeps up to twice GOMAXPROCS instances registered (at least minMaxLive): only running
+// goroutines use instances outside a Session, so more are only needed transiently.
+func newInstancePool(cfg *Config) *instancePool {
+	return &instancePool{cfg: cfg, maxLive: max(minMaxLive, 2*runtime.GOMAXPROCS(0))}
+}
+
+// registered returns the instances currently registered in live.
+func (p *instancePool) registered() []*drbg {
+	if live := p.live.Load(); live != nil {
+		return *live
+	}
+	return nil
+}
+
+// register adds d to live. p.mu must be held.
+func (p *instancePool) register(d *drbg) {
+	live := append(slices.Clip(p.registered()), d)
+	p.live.Store(&live)
+}
+
+// unregister removes d, which must be uninstantiated, from live and adds its counters to retired.
+// p.mu must be held.
+func (p *instancePool) unregister(d *drbg) {
+	live := slices.DeleteFunc(slices.Clone(p.registered()), func(e *drbg) bool { return e == d })
+	p.live.Store(&live)
+	d.stats.addTo(&p.retired)
+}
+
+// get takes an instance from the pool, reusing an idle registered instance or constructing a new one if
+// the sync.Pool has none.
+//
+// The instance is brought up to date with the most recent Reseed before it is returned. The caller has
+// exclusive use of the instance until it is passed to put.
+//
+// Returns ErrClosed after Close.
+func (p *instancePool) get() (*drbg, error) {
+	if p.closed.Load() {
+		return nil, ErrClosed
+	}
+
+	d, _ := p.pool.Get().(*drbg)
+	if d == nil || !d.claim.CompareAndSwap(instanceIdle, instanceBusy) {
+		// Either the cache is empty, or it held a stale reference to an instance that was since
+		// claimed from live or uninstantiated; that reference is dropped.
+		var err error
+		if d, err = p.getSlow(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.refresh(d); err != nil {
+		p.put(d)
+		return nil, err
+	}
+	return d, nil
+}
+
+// getSlow claims an idle registered instance, or constructs and registers a new one. The registry is
+// scanned without holding p.mu.
+func (p *instancePool) getSlow() (*drbg, error) {
+	for _, d := range p.registered() {
+		if d.claim.CompareAndSwap(instanceIdle, instanceBusy) {
+			return d, nil
+		}
+	}
+
+	d, err := p.create()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed.Load() {
+		d.uninstantiate()
+		return nil, ErrClosed
+	}
+	d.claim.Store(instanceBusy)
+	p.register(d)
+	return d, nil
+}
+
+// put returns an instance taken with get to the pool. The instance is retired instead if more than maxLive
+// instances are registered, and uninstantiated after Close.
+func (p *instancePool) put(d *drbg) {
+	if len(p.registered()) > p.maxLive && !p.closed.Load() {
+		d.claim.Store(instanceClosed)
+		d.uninstantiate()
+		p.mu.Lock()
+		p.unregister(d)
+		p.mu.Unlock()
+		return
+	}
+
+	d.claim.Store(instanceIdle)
+
+	// Close may have run while d was in use; whichever of put and Close claims d uninstantiates it.
+	if p.closed.Load() {
+		if d.claim.CompareAndSwap(instanceIdle, instanceClosed) {
+			d.uninstantiate()
+			p.mu.Lock()
+			p.unregister(d)
+			p.mu.Unlock()
+		}
+		return
+	}
+	p.pool.Put(d)
+}
+
+// create constructs a new instance, retrying up to MaxInitRetries times.
+//
+// The instance records the epoch observed before it was seeded, so a Reseed that runs concurrently with
+// its construction is applied at its next refresh.
+func (p *instancePool) create() (*drbg, error) {
+	epoch := p.epoch.Load()
+
+	var (
+		d   *drbg
+		err error
+	)
+	for r := 0; r < p.cfg.MaxInitRetries; r++ {
+		if d, err = newDRBG(p.cfg); err == nil {
+			d.poolEpoch = epoch
+			d.instance = p.created.Add(1)
+			d.auditEvent(AuditInstantiate, "")
+			return d, nil
+		}
+	}
+	return nil, &InstantiationError{Attempts: p.cfg.MaxInitRetries, Err: err}
+}
+
+// reseed advances the pool's epoch, so that every instance reseeds with additionalInput before its next
+// output, and reseeds one instance immediately so that entropy source failures are reported.
+//
+// Returns the error of the immediate reseed, if any.
+func (p *instancePool) reseed(additionalInput []byte) error {
+	if p.closed.Load() {
+		return ErrClosed
+	}
+
+	// SP 800-90C §4.2: An RBG1 is seeded once and may not be reseeded.
+	if p.cfg.Construction == ConstructionRBG1 {
+		return ErrReseedNotPermitted
+	}
+
+	p.mu.Lock()
+	p.reseedInput = append(p.reseedInput[:0], additionalInput...)
+	p.epoch.Add(1)
+	p.mu.Unlock()
+
+	d, err := p.get()
+	if err != nil {
+		return err
+	}
+	p.put(d)
+	return nil
+}
+
+// refresh reseeds d, which the caller owns, if a Reseed has run since d last observed the pool's epoch.
+// It costs a single atomic load when d is up to date.
+//
+// If the reseed fails, d keeps its stale epoch and retries at its next refresh.
+func (p *instancePool) refresh(d *drbg) error {
+	if d.poolEpoch == p.epoch.Load() {
+		return nil
+	}
+
+	p.mu.Lock()
+	epoch := p.epoch.Load()
+	additionalInput := bytes.Clone(p.reseedInput)
+	p.mu.Unlock()
+
+	if err := d.Reseed(additionalInput); err != nil {
+		return err
+	}
+	d.poolEpoch = epoch
+	return nil
+}
+
+// close marks the pool closed and uninstantiates and unregisters every idle instance, waiting for their
+// background rekeys. Instances in use are uninstantiated and unregistered by put when they are returned.
+// The shard's entropy prefetch buffer, if any, is zeroized.
+func (p *instancePool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed.Store(true)
+	var inUse []*drbg
+	for _, d := range p.registered() {
+		if d.claim.CompareAndSwap(instanceIdle, instanceClosed) {
+			d.uninstantiate()
+			d.stats.addTo(&p.retired)
+			continue
+		}
+		inUse = append(inUse, d)
+	}
+	p.live.Store(&inUse)
+	if p.cfg.prefetch != nil {
+		p.cfg.prefetch.wipe()
+	}
+	clear(p.reseedInput)
+}
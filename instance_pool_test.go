@@ -0,0 +1,386 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_InstancePool_ReseedReachesAll verifies that Reader.Reseed reaches every live instance of every
+// shard: no instance, whether idle in a pool or held by a Session, generates output from its pre-Reseed
+// state afterwards.
+func Test_InstancePool_ReseedReachesAll(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(2), WithShardStrategy(ShardStrategyRoundRobin))
+	is.NoError(err)
+	r := rdr.(*reader)
+
+	// Create several instances per shard, and record the key of each.
+	sessions := make([]Session, 8)
+	old := make(map[[32]byte]bool)
+	for i := range sessions {
+		sessions[i], err = r.Acquire()
+		is.NoError(err)
+		old[sessions[i].d.state.key] = true
+	}
+	held := sessions[:2]
+	for i := 2; i < len(sessions); i++ {
+		sessions[i].Release()
+	}
+
+	is.NoError(r.Reseed([]byte("rotate")))
+
+	// Instances held across the Reseed are reseeded before their next output.
+	buf := make([]byte, 16)
+	for _, s := range held {
+		is.True(old[s.d.state.key], "a held instance is not reseeded concurrently with its owner")
+		_, err = s.Read(buf)
+		is.NoError(err)
+		is.False(old[s.d.state.key], "a held instance should be reseeded before its next output")
+	}
+
+	// Every instance subsequently taken from a pool has been reseeded.
+	for range sessions {
+		s, err := r.Acquire()
+		is.NoError(err)
+		is.False(old[s.d.state.key], "a pooled instance should be reseeded before it is returned")
+		is.Equal(s.pool.epoch.Load(), s.d.poolEpoch)
+		defer s.Release()
+	}
+	for _, s := range held {
+		s.Release()
+	}
+}
+
+// Test_InstancePool_ReseedFailure verifies that an instance whose reseed failed retries before its next
+// output, and that the output fails if the retry fails.
+func Test_InstancePool_ReseedFailure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &toggleSource{}
+	rdr, err := NewReader(WithEntropySource(src), WithShards(1))
+	is.NoError(err)
+	r := rdr.(*reader)
+
+	s, err := r.Acquire()
+	is.NoError(err)
+	key := s.d.state.key
+
+	src.fail.Store(true)
+	is.ErrorIs(r.Reseed(nil), errToggleSource)
+	_, err = s.Read(make([]byte, 16))
+	is.ErrorIs(err, errToggleSource, "output must not be generated before the pending reseed succeeds")
+
+	src.fail.Store(false)
+	_, err = s.Read(make([]byte, 16))
+	is.NoError(err)
+	is.NotEqual(key, s.d.state.key)
+	is.Equal(r.pools[0].epoch.Load(), s.d.poolEpoch)
+	s.Release()
+}
+
+// assertUninstantiated verifies that d holds no key material.
+func assertUninstantiated(is *assert.Assertions, d *drbg) {
+	is.Equal(instanceClosed, d.claim.Load())
+	is.Nil(d.state)
+	for i := range d.slots {
+		is.Equal([32]byte{}, d.slots[i].key, "keys should be zeroized")
+		is.Equal([16]byte{}, d.slots[i].v, "counters should be zeroized")
+		is.Nil(d.slots[i].block)
+	}
+	is.Equal([16]byte{}, d.v)
+	is.Equal(seedScratch{}, d.rekeyBuf)
+	is.Equal(seedScratch{}, d.reseedBuf)
+}
+
+// Test_Reader_Close verifies that Close waits for background rekeys, zeroizes every idle instance, and
+// makes later calls return ErrClosed.
+func Test_Reader_Close(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(
+		WithShards(2),
+		WithEnableKeyRotation(true),
+		WithMaxBytesPerKey(64),
+		WithKeystreamCache(256),
+	)
+	is.NoError(err)
+	r := rdr.(*reader)
+
+	// Create several instances per shard, each with a background rekey in flight.
+	sessions := make([]Session, 6)
+	rekeying := make([]*drbg, len(sessions))
+	for i := range sessions {
+		sessions[i], err = r.Acquire()
+		is.NoError(err)
+		_, err = sessions[i].Read(make([]byte, 64))
+		is.NoError(err)
+		is.True(sessions[i].d.rekeying)
+		rekeying[i] = sessions[i].d
+	}
+	for i := range sessions {
+		sessions[i].Release()
+	}
+
+	var live []*drbg
+	for _, p := range r.pools {
+		live = append(live, p.registered()...)
+	}
+	is.GreaterOrEqual(len(live), 6)
+
+	is.NoError(r.Close())
+	for _, d := range rekeying {
+		is.NotEqual(rekeyPending, d.rekeyResult.Load(), "Close should wait for background rekeys")
+	}
+	for _, d := range live {
+		assertUninstantiated(is, d)
+	}
+
+	buf := make([]byte, 16)
+	_, err = r.Read(buf)
+	is.ErrorIs(err, ErrClosed)
+	_, err = r.ReadWithAdditionalInput(buf, nil)
+	is.ErrorIs(err, ErrClosed)
+	is.ErrorIs(r.Reseed(nil), ErrClosed)
+	_, err = r.Acquire()
+	is.ErrorIs(err, ErrClosed)
+	is.NoError(r.Close(), "Close should be idempotent")
+	is.Equal(2, r.Config().Shards, "Config should remain available")
+}
+
+// Test_Reader_Close_Session verifies that an instance in use when Close is called is zeroized when it is
+// returned, and that its Session refuses further reads.
+func Test_Reader_Close_Session(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(1))
+	is.NoError(err)
+	s, err := rdr.Acquire()
+	is.NoError(err)
+	d := s.d
+
+	is.NoError(rdr.Close())
+	is.Equal(instanceBusy, d.claim.Load(), "an instance in use should not be zeroized under its owner")
+	_, err = s.Read(make([]byte, 16))
+	is.ErrorIs(err, ErrClosed)
+	_, err = s.ReadWithAdditionalInput(make([]byte, 16), nil)
+	is.ErrorIs(err, ErrClosed)
+
+	s.Release()
+	assertUninstantiated(is, d)
+}
+
+// Test_Reader_Close_Prefetch verifies that Close zeroizes each shard's entropy prefetch buffer.
+func Test_Reader_Close_Prefetch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(1), WithPredictionResistance(true), WithEntropyPrefetch(4096, time.Minute))
+	is.NoError(err)
+	r := rdr.(*reader)
+	_, err = r.Read(make([]byte, 16))
+	is.NoError(err)
+
+	p := r.pools[0].cfg.prefetch
+	is.Less(p.off, len(p.buf), "the prefetch buffer should hold unserved entropy")
+	is.NoError(r.Close())
+	is.Equal(make([]byte, len(p.buf)), p.buf)
+}
+
+// Test_Reader_Close_Concurrent verifies that Close is safe while reads are in progress: every read either
+// succeeds or fails with ErrClosed, and every instance is zeroized once the readers finish.
+func Test_Reader_Close_Concurrent(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(2))
+	is.NoError(err)
+	r := rdr.(*reader)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 32)
+			for {
+				if _, err := r.Read(buf); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	var live []*drbg
+	for _, p := range r.pools {
+		live = append(live, p.registered()...)
+	}
+	is.NoError(r.Close())
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		is.ErrorIs(err, ErrClosed)
+	}
+	for _, d := range live {
+		assertUninstantiated(is, d)
+	}
+}
+
+// Test_InstancePool_SurvivesGC verifies that instances dropped from the sync.Pool cache by a garbage
+// collection are reused rather than replaced.
+func Test_InstancePool_SurvivesGC(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(1))
+	is.NoError(err)
+	r := rdr.(*reader)
+	p := r.pools[0]
+
+	runtime.GC()
+	runtime.GC()
+	for i := 0; i < 10; i++ {
+		_, err = r.Read(make([]byte, 16))
+		is.NoError(err)
+	}
+
+	is.Len(p.registered(), 1, "the registered instance should be reused")
+}
+
+// Test_InstancePool_Bounded verifies that instances left over from a burst of concurrent use are retired
+// rather than kept registered, and that Stats still reports their activity.
+func Test_InstancePool_Bounded(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	rdr, err := NewReader(WithShards(1))
+	is.NoError(err)
+	r := rdr.(*reader)
+	p := r.pools[0]
+
+	// Hold more instances than the pool keeps, reading once from each.
+	sessions := make([]Session, p.maxLive+4)
+	for i := range sessions {
+		sessions[i], err = r.Acquire()
+		is.NoError(err)
+		_, err = sessions[i].Read(make([]byte, 16))
+		is.NoError(err)
+	}
+	is.Len(p.registered(), len(sessions))
+
+	var surplus []*drbg
+	for _, s := range sessions[:4] {
+		surplus = append(surplus, s.d)
+	}
+	for i := range sessions {
+		sessions[i].Release()
+	}
+	is.Len(p.registered(), p.maxLive, "instances above maxLive should be retired when returned")
+	for _, d := range surplus {
+		assertUninstantiated(is, d)
+	}
+
+	st := r.Stats().Total
+	is.Equal(uint64(len(sessions)), st.InstancesCreated)
+	is.Equal(uint64(len(sessions)), st.Requests, "retired instances should still be counted")
+
+	is.NoError(r.Close())
+	is.Empty(p.registered(), "Close should unregister every instance")
+	is.Equal(uint64(len(sessions)), r.Stats().Total.Requests)
+}
+
+// Test_InstancePool_Retired verifies that a retired instance is recorded as zeroized in the audit trail and
+// that its counters remain in Stats through the pool's retired totals.
+func Test_InstancePool_Retired(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	sink := NewMemoryAuditSink()
+	rdr, err := NewReader(WithShards(1), WithAuditLog(NewAuditLog(sink)))
+	is.NoError(err)
+	defer rdr.Close()
+	r := rdr.(*reader)
+	p := r.pools[0]
+
+	sessions := make([]Session, p.maxLive+1)
+	for i := range sessions {
+		sessions[i], err = r.Acquire()
+		is.NoError(err)
+		_, err = sessions[i].Read(make([]byte, 16))
+		is.NoError(err)
+	}
+	retired := sessions[0].d
+	sessions[0].Release()
+
+	is.Len(p.registered(), p.maxLive)
+	is.NotContains(p.registered(), retired)
+	var zeroized []uint64
+	for _, rec := range sink.Records() {
+		if rec.Event == AuditZeroize {
+			zeroized = append(zeroized, rec.Instance)
+		}
+	}
+	is.Equal([]uint64{retired.instance}, zeroized, "the retired instance should be recorded as zeroized")
+
+	p.mu.Lock()
+	is.Equal(uint64(1), p.retired.Requests)
+	is.Equal(uint64(16), p.retired.BytesGenerated)
+	p.mu.Unlock()
+	is.Equal(uint64(len(sessions)), r.Stats().Total.Requests, "Stats should include the retired instance")
+
+	for i := 1; i < len(sessions); i++ {
+		sessions[i].Release()
+	}
+}
+
+// Test_InstancePool_InstantiationError verifies that instantiation failures surface as *InstantiationError
+// from NewReader and from requests that need a new instance.
+func Test_InstancePool_InstantiationError(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &toggleSource{}
+	src.fail.Store(true)
+	_, err := NewReader(WithEntropySource(src), WithMaxInitRetries(2))
+	var ie *InstantiationError
+	is.ErrorAs(err, &ie)
+	is.Equal(2, ie.Attempts)
+	is.ErrorIs(err, ErrInstantiationFailed)
+	is.ErrorIs(err, errToggleSource)
+
+	src.fail.Store(false)
+	rdr, err := NewReader(WithEntropySource(src), WithShards(1))
+	is.NoError(err)
+
+	// Hold the only instance, so the next request must instantiate another.
+	s, err := rdr.Acquire()
+	is.NoError(err)
+	defer s.Release()
+
+	src.fail.Store(true)
+	_, err = rdr.Read(make([]byte, 16))
+	is.ErrorIs(err, ErrInstantiationFailed)
+	_, err = rdr.Acquire()
+	is.ErrorIs(err, ErrInstantiationFailed)
+
+	src.fail.Store(false)
+	_, err = rdr.Read(make([]byte, 16))
+	is.NoError(err)
+}
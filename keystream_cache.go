@@ -0,0 +1,91 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"fmt"
+)
+
+// keystreamCacheMaxRead is the largest request served from the keystream cache. Larger requests are
+// generated directly into the caller's buffer.
+const keystreamCacheMaxRead = 64
+
+// validateKeystreamCache checks the KeystreamCacheSize setting.
+//
+// The cache size must be a multiple of the AES block size, at least keystreamCacheMaxRead, and no larger
+// than MaxBytesPerRequest (each refill is a single SP 800-90A generate request). Prediction resistance
+// requires fresh entropy before every output and cannot be combined with a pre-generated keystream.
+func validateKeystreamCache(cfg *Config) error {
+	size := cfg.KeystreamCacheSize
+	if size == 0 {
+		return nil
+	}
+	if size < keystreamCacheMaxRead || size > MaxBytesPerRequest || size%16 != 0 {
+		return fmt.Errorf("%w: KeystreamCacheSize %d: must be a multiple of 16 between %d and %d", ErrInvalidConfig, size, keystreamCacheMaxRead, MaxBytesPerRequest)
+	}
+	if cfg.PredictionResistance {
+		return fmt.Errorf("%w: KeystreamCacheSize %d: keystream cache cannot be used with prediction resistance", ErrInvalidConfig, size)
+	}
+	return nil
+}
+
+// readCached serves b from the instance's keystream cache, refilling it from st when it holds
+// fewer than len(b) unread bytes.
+//
+// Served bytes are zeroized immediately, so the cache never retains output already returned to a
+// caller (backtracking resistance). Unread bytes left over at refill are discarded and zeroized,
+// never served. Each refill is one generate request: it advances V, runs the continuous health test
+// when enabled, and is what counts toward MaxBytesPerKey.
+//
+// Returns the number of keystream bytes generated by a refill (zero if none was needed).
+func (d *drbg) readCached(b []byte, st *state) (int, error) {
+	n := len(b)
+	generated := 0
+
+	if len(d.cache)-d.cacheOff < n {
+		chunk := d.cache[:cap(d.cache)]
+		clear(chunk)
+
+		// Generate the next chunk from the persisted counter.
+		copy(d.encV[:], d.v[:])
+		d.fillBlocks(chunk, st, &d.encV)
+
+		if d.config.ContinuousHealthTest {
+			if err := d.continuousHealthTest(chunk); err != nil {
+				d.wipeCache()
+				return 0, err
+			}
+		}
+
+		copy(d.v[:], d.encV[:])
+		d.cache = chunk
+		d.cacheOff = 0
+		generated = len(chunk)
+	}
+
+	served := d.cache[d.cacheOff : d.cacheOff+n]
+	copy(b, served)
+	clear(served)
+	d.cacheOff += n
+
+	return generated, nil
+}
+
+// wipeCache zeroizes and empties the keystream cache, so no bytes generated under the current state are
+// served after a reseed, rekey, or fork.
+func (d *drbg) wipeCache() {
+	if d.cache == nil {
+		return
+	}
+	clear(d.cache[:cap(d.cache)])
+	d.cache = d.cache[:0]
+	d.cacheOff = 0
+}
+
+// cacheEnabled reports whether a request of n bytes is served from the keystream cache.
+func (d *drbg) cacheEnabled(n int) bool {
+	return d.cache != nil && n <= keystreamCacheMaxRead
+}
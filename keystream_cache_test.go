@@ -0,0 +1,170 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCachedDRBG returns an instance with a keystream cache of size bytes, plus an uncached
+// instance sharing the same state for reference output.
+func newCachedDRBG(t *testing.T, size int) (*drbg, *drbg) {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.KeystreamCacheSize = size
+	cached, err := newDRBG(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refCfg := DefaultConfig()
+	ref, err := newDRBG(&refCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloneState(t, ref, cached)
+	return cached, ref
+}
+
+// Test_KeystreamCache_Output verifies that small cached reads return the same keystream as direct
+// generation, without discarding partial-block tails.
+func Test_KeystreamCache_Output(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	d, ref := newCachedDRBG(t, 128)
+
+	var got []byte
+	buf := make([]byte, 10)
+	for i := 0; i < 12; i++ {
+		_, err := d.Read(buf)
+		is.NoError(err)
+		got = append(got, buf...)
+	}
+
+	// 12 reads of 10 bytes fit in one 128-byte chunk.
+	want := make([]byte, 128)
+	_, err := ref.Read(want)
+	is.NoError(err)
+	is.Equal(want[:120], got)
+	is.Equal(ref.v, d.v, "a refill should advance V by one chunk")
+
+	// The next read no longer fits; the unread tail is discarded and a new chunk is generated.
+	_, err = d.Read(buf)
+	is.NoError(err)
+	_, err = ref.Read(want)
+	is.NoError(err)
+	is.Equal(want[:10], buf)
+}
+
+// Test_KeystreamCache_WipesServedBytes verifies that bytes already returned to a caller are zeroized.
+func Test_KeystreamCache_WipesServedBytes(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	d, _ := newCachedDRBG(t, 256)
+	buf := make([]byte, 32)
+	_, err := d.Read(buf)
+	is.NoError(err)
+
+	is.Equal(32, d.cacheOff)
+	is.Equal(make([]byte, 32), d.cache[:32], "served bytes must be zeroized")
+	is.False(bytes.Equal(make([]byte, 32), d.cache[32:64]), "unread bytes should remain cached")
+}
+
+// Test_KeystreamCache_WipedOnReseed verifies that reseed, rekey, and fork discard cached keystream.
+func Test_KeystreamCache_WipedOnReseed(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		trigger func(d *drbg)
+	}{
+		{"Reseed", func(d *drbg) { _ = d.Reseed(nil) }},
+		{"Rekey", func(d *drbg) {
+			d.rekeying = true
+			d.rekeyWG.Add(1)
+			d.asyncRekey()
+		}},
+		{"Fork", func(d *drbg) { d.forkEpoch-- }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			d, _ := newCachedDRBG(t, 256)
+			buf := make([]byte, 16)
+			_, err := d.Read(buf)
+			is.NoError(err)
+
+			// The bytes that would have been served next under the old state.
+			next := bytes.Clone(d.cache[d.cacheOff : d.cacheOff+16])
+			old := d.state
+
+			tc.trigger(d)
+			_, err = d.Read(buf)
+			is.NoError(err)
+			is.NotSame(old, d.state, "state should have been replaced")
+			is.NotEqual(next, buf, "keystream cached under the old state must not be served")
+			is.Equal(16, d.cacheOff, "cache should have been refilled from the new state")
+		})
+	}
+}
+
+// Test_KeystreamCache_Accounting verifies that refills, not reads, count toward MaxBytesPerKey, and that
+// the health test runs on each refill.
+func Test_KeystreamCache_Accounting(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.KeystreamCacheSize = 512
+	cfg.EnableKeyRotation = true
+	cfg.ContinuousHealthTest = true
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	buf := make([]byte, 8)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Equal(uint64(512), d.usage, "a refill should count the whole chunk")
+	is.True(d.healthTestReady, "the refill should be health tested")
+
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Equal(uint64(512), d.usage, "a cache hit generates no new keystream")
+	is.Equal(uint64(2), d.requests, "every read is a request")
+
+	// Requests larger than the cached read limit bypass the cache.
+	big := make([]byte, keystreamCacheMaxRead+1)
+	_, err = d.Read(big)
+	is.NoError(err)
+	is.Equal(16, d.cacheOff)
+}
+
+// Test_KeystreamCache_Validate verifies KeystreamCacheSize validation in NewReader.
+func Test_KeystreamCache_Validate(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	_, err := NewReader(WithKeystreamCache(4096), WithShards(1))
+	is.NoError(err)
+
+	for _, size := range []int{-16, 16, 100, MaxBytesPerRequest + 16} {
+		_, err = NewReader(WithKeystreamCache(size))
+		is.Error(err, "size %d", size)
+	}
+
+	_, err = NewReader(WithKeystreamCache(4096), WithPredictionResistance(true))
+	is.Error(err)
+}
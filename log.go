@@ -0,0 +1,149 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// redacted replaces the value of a sensitive field when a Config is logged.
+const redacted = "REDACTED"
+
+// LogValue implements slog.LogValuer, so that a Config can be logged without exposing sensitive fields.
+//
+// Personalization is replaced by "REDACTED" when set. Entropy sources are reported by name, and the
+// remaining function and interface fields (RekeyFailureHandler, GenerationIDProvider, Logger, Hooks,
+// AuditLog) only by whether they are set.
+func (cfg Config) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("key_size", int(cfg.KeySize)),
+		slog.String("construction", cfg.Construction.String()),
+		slog.Int("shards", cfg.Shards),
+		slog.String("shard_strategy", cfg.ShardStrategy.String()),
+		slog.Bool("prediction_resistance", cfg.PredictionResistance),
+		slog.Duration("reseed_interval", cfg.ReseedInterval),
+		slog.Uint64("reseed_requests", cfg.ReseedRequests),
+		slog.Uint64("fork_detection_interval", cfg.ForkDetectionInterval),
+		slog.Bool("enable_key_rotation", cfg.EnableKeyRotation),
+		slog.Uint64("max_bytes_per_key", cfg.MaxBytesPerKey),
+		slog.String("rekey_mode", cfg.RekeyMode.String()),
+		slog.String("rekey_failure_policy", cfg.RekeyFailurePolicy.String()),
+		slog.Bool("rekey_failure_handler", cfg.RekeyFailureHandler != nil),
+		slog.Int("max_rekey_attempts", cfg.MaxRekeyAttempts),
+		slog.Duration("rekey_backoff", cfg.RekeyBackoff),
+		slog.Duration("max_rekey_backoff", cfg.MaxRekeyBackoff),
+		slog.Int("max_init_retries", cfg.MaxInitRetries),
+		slog.String("entropy_source", cfg.entropySource().Name()),
+		slog.Duration("entropy_timeout", cfg.EntropyTimeout),
+		slog.Int("entropy_prefetch_size", cfg.EntropyPrefetchSize),
+		slog.Duration("entropy_prefetch_max_age", cfg.EntropyPrefetchMaxAge),
+		slog.Bool("generation_id_provider", cfg.GenerationIDProvider != nil),
+		slog.Duration("generation_id_check_interval", cfg.GenerationIDCheckInterval),
+		slog.Int("keystream_cache_size", cfg.KeystreamCacheSize),
+		slog.Bool("use_zero_buffer", cfg.UseZeroBuffer),
+		slog.Int("default_buffer_size", cfg.DefaultBufferSize),
+		slog.Bool("enable_self_tests", cfg.EnableSelfTests),
+		slog.Bool("enable_zeroization", cfg.EnableZeroization),
+		slog.Bool("continuous_health_test", cfg.ContinuousHealthTest),
+		slog.String("expvar_name", cfg.ExpvarName),
+		slog.Bool("logger", cfg.Logger != nil),
+		slog.Bool("hooks", cfg.Hooks.set()),
+		slog.Bool("audit_log", cfg.AuditLog != nil),
+		slog.String("profile", cfg.Profile.String()),
+	}
+	if len(cfg.Personalization) > 0 {
+		attrs = append(attrs, slog.String("personalization", redacted))
+	}
+	if cfg.AdditionalInputSource != nil {
+		attrs = append(attrs, slog.String("additional_input_source", cfg.AdditionalInputSource.Name()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logger returns the configured Logger if it is enabled at level, or nil. Callers build their attributes
+// only when it returns non-nil, so disabled events cost a nil check (or an Enabled call) and no allocations.
+func (cfg *Config) logger(level slog.Level) *slog.Logger {
+	if l := cfg.Logger; l != nil && l.Enabled(context.Background(), level) {
+		return l
+	}
+	return nil
+}
+
+// logReseed records a reseed of the instance, successful or not.
+func (d *drbg) logReseed(cause ReseedCause, elapsed time.Duration, err error) {
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelError
+	}
+	l := d.config.logger(level)
+	if l == nil {
+		return
+	}
+	if err != nil {
+		l.LogAttrs(context.Background(), level, "ctrdrbg: reseed failed",
+			slog.Int("shard", d.config.shard), slog.String("cause", cause.String()),
+			slog.Duration("duration", elapsed), slog.Any("error", err))
+		return
+	}
+	l.LogAttrs(context.Background(), level, "ctrdrbg: reseed",
+		slog.Int("shard", d.config.shard), slog.String("cause", cause.String()),
+		slog.Duration("duration", elapsed))
+}
+
+// logRekey records a successful key rotation.
+func (d *drbg) logRekey(elapsed time.Duration) {
+	if l := d.config.logger(slog.LevelInfo); l != nil {
+		l.LogAttrs(context.Background(), slog.LevelInfo, "ctrdrbg: rekey",
+			slog.Int("shard", d.config.shard), slog.String("mode", d.config.RekeyMode.String()),
+			slog.Duration("duration", elapsed))
+	}
+}
+
+// logRekeyFailed records a key rotation that exhausted its attempts.
+func (d *drbg) logRekeyFailed(re *RekeyError) {
+	if l := d.config.logger(slog.LevelError); l != nil {
+		l.LogAttrs(context.Background(), slog.LevelError, "ctrdrbg: rekey failed",
+			slog.Int("shard", d.config.shard), slog.String("mode", re.Mode.String()),
+			slog.String("policy", d.config.RekeyFailurePolicy.String()),
+			slog.Int("attempts", re.Attempts), slog.Uint64("usage", re.Usage), slog.Any("error", re.Err))
+	}
+}
+
+// logDetected records detection of an event that invalidates the instance's state: a process fork or a
+// VM generation change. The reseed that follows is logged separately.
+func (d *drbg) logDetected(cause ReseedCause) {
+	if l := d.config.logger(slog.LevelWarn); l != nil {
+		l.LogAttrs(context.Background(), slog.LevelWarn, "ctrdrbg: state duplication detected",
+			slog.Int("shard", d.config.shard), slog.String("cause", cause.String()))
+	}
+}
+
+// logHealthTestFailed records a continuous health test failure.
+func (d *drbg) logHealthTestFailed() {
+	if l := d.config.logger(slog.LevelError); l != nil {
+		l.LogAttrs(context.Background(), slog.LevelError, "ctrdrbg: continuous health test failed",
+			slog.Int("shard", d.config.shard))
+	}
+}
+
+// logSelfTests records the result of the Known Answer Tests run by NewReader.
+func logSelfTests(cfg *Config, elapsed time.Duration, err error) {
+	level := slog.LevelInfo
+	msg := "ctrdrbg: self-tests passed"
+	if err != nil {
+		level = slog.LevelError
+		msg = "ctrdrbg: self-tests failed"
+	}
+	if l := cfg.logger(level); l != nil {
+		attrs := []slog.Attr{slog.Duration("duration", elapsed)}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+		}
+		l.LogAttrs(context.Background(), level, msg, attrs...)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLogger returns a Debug-level JSON logger and a function returning the events logged so far.
+func newTestLogger(t *testing.T) (*slog.Logger, func() []map[string]any) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return l, func() []map[string]any {
+		var events []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var e map[string]any
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, e)
+		}
+		return events
+	}
+}
+
+// findEvent returns the first event with the given message, or nil.
+func findEvent(events []map[string]any, msg string) map[string]any {
+	for _, e := range events {
+		if e["msg"] == msg {
+			return e
+		}
+	}
+	return nil
+}
+
+// Test_Config_LogValue verifies that logging a Config redacts Personalization and reduces functions and
+// interfaces to names or presence flags.
+func Test_Config_LogValue(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	cfg := DefaultConfig()
+	WithPersonalization([]byte("tenant-secret-42"))(&cfg)
+	WithRekeyFailureHandler(func(*RekeyError) {})(&cfg)
+	l.Info("config", "cfg", cfg)
+	l.Info("config", "cfg", &cfg)
+
+	out := buf.String()
+	is.NotContains(out, "tenant-secret-42")
+	is.NotContains(out, "dGVuYW50", "the base64 encoding of Personalization must not appear either")
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e struct {
+			Cfg map[string]any `json:"cfg"`
+		}
+		is.NoError(json.Unmarshal([]byte(line), &e))
+		is.Equal(redacted, e.Cfg["personalization"])
+		is.Equal("crypto/rand", e.Cfg["entropy_source"])
+		is.Equal(true, e.Cfg["rekey_failure_handler"])
+		is.Equal(float64(KeySize256), e.Cfg["key_size"])
+	}
+
+	buf.Reset()
+	l.Info("config", "cfg", DefaultConfig())
+	is.NotContains(buf.String(), "personalization", "an unset Personalization should be omitted")
+}
+
+// Test_WithLogger verifies the reseed, fork detection, and self-test events.
+func Test_WithLogger(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	l, events := newTestLogger(t)
+	r, err := NewReader(WithShards(1), WithLogger(l), WithSelfTests(true), WithReseedRequests(1))
+	is.NoError(err)
+	defer r.Close()
+
+	e := findEvent(events(), "ctrdrbg: self-tests passed")
+	is.NotNil(e)
+	is.Equal("INFO", e["level"])
+
+	buf := make([]byte, 32)
+	for i := 0; i < 2; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+	e = findEvent(events(), "ctrdrbg: reseed")
+	is.NotNil(e)
+	is.Equal("DEBUG", e["level"])
+	is.Equal("request_count", e["cause"])
+	is.Equal(float64(0), e["shard"])
+	is.Contains(e, "duration")
+
+	// Simulate a fork by invalidating the instance's epoch.
+	s, err := r.Acquire()
+	is.NoError(err)
+	s.d.forkEpoch--
+	_, err = s.Read(buf)
+	is.NoError(err)
+	s.Release()
+
+	e = findEvent(events(), "ctrdrbg: state duplication detected")
+	is.NotNil(e)
+	is.Equal("WARN", e["level"])
+	is.Equal("fork", e["cause"])
+
+	var forkReseed bool
+	for _, e := range events() {
+		if e["msg"] == "ctrdrbg: reseed" && e["cause"] == "fork" {
+			forkReseed = true
+		}
+	}
+	is.True(forkReseed, "the fork reseed should be logged with its cause")
+}
+
+// Test_WithLogger_Failures verifies the rekey, rekey failure, and health test failure events.
+func Test_WithLogger_Failures(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	l, events := newTestLogger(t)
+	d, src, _ := newFailingRekeyDRBG(t, RekeyModeSync, RekeyFailureContinue)
+	d.config.Logger = l
+
+	buf := make([]byte, 64)
+	_, err := d.Read(buf)
+	is.NoError(err)
+	e := findEvent(events(), "ctrdrbg: rekey failed")
+	is.NotNil(e)
+	is.Equal("ERROR", e["level"])
+	is.Equal("sync", e["mode"])
+	is.Equal(float64(2), e["attempts"])
+	is.Contains(e["error"], errToggleSource.Error())
+
+	src.fail.Store(false)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	e = findEvent(events(), "ctrdrbg: rekey")
+	is.NotNil(e)
+	is.Equal("INFO", e["level"])
+	is.Contains(e, "duration")
+
+	block := make([]byte, 16)
+	is.NoError(d.continuousHealthTest(block))
+	is.ErrorIs(d.continuousHealthTest(block), ErrHealthTestFailed)
+	e = findEvent(events(), "ctrdrbg: continuous health test failed")
+	is.NotNil(e)
+	is.Equal("ERROR", e["level"])
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"weak"
+)
+
+// ErrExpvarNameInUse is returned by NewReader when the name set with WithExpvar is already published.
+var ErrExpvarNameInUse = errors.New("ctrdrbg: expvar name already in use")
+
+// metricsContentType is the media type of the Prometheus text exposition format, version 0.0.4.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// MetricsHandler returns an http.Handler that serves the Stats of r in the Prometheus text exposition
+// format, so the DRBG can be scraped without a Prometheus client library.
+//
+// Every metric carries a shard label. The following metrics are exported:
+//
+//	ctrdrbg_instances_created_total      counter    DRBG instances instantiated
+//	ctrdrbg_requests_total               counter    generate requests
+//	ctrdrbg_generated_bytes_total        counter    output bytes returned to callers
+//	ctrdrbg_reseeds_total                counter    reseeds, labeled by cause
+//	ctrdrbg_rekeys_total                 counter    successful key rotations
+//	ctrdrbg_rekey_failures_total         counter    key rotations that exhausted MaxRekeyAttempts
+//	ctrdrbg_rekey_duration_seconds       histogram  seed derivation time of successful key rotations
+//	ctrdrbg_health_test_failures_total   counter    continuous health test failures
+//	ctrdrbg_entropy_bytes_total          counter    entropy input bytes consumed
+//
+// The reseed cause label is one of interval, request_count, prediction_resistance, fork, generation,
+// manual, additional_input, or rekey_failure.
+//
+// Example:
+//
+//	http.Handle("/metrics", ctrdrbg.MetricsHandler(r))
+func MetricsHandler(r Interface) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		var buf bytes.Buffer
+		writeMetrics(&buf, r.Stats())
+		w.Header().Set("Content-Type", metricsContentType)
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// writeMetrics writes s to buf in the Prometheus text exposition format.
+func writeMetrics(buf *bytes.Buffer, s Stats) {
+	counter := func(name, help string, value func(*ShardStats) uint64) {
+		fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for i := range s.Shards {
+			fmt.Fprintf(buf, "%s{shard=\"%d\"} %d\n", name, i, value(&s.Shards[i]))
+		}
+	}
+
+	counter("ctrdrbg_instances_created_total", "DRBG instances instantiated.",
+		func(sh *ShardStats) uint64 { return sh.InstancesCreated })
+	counter("ctrdrbg_requests_total", "Generate requests that returned output.",
+		func(sh *ShardStats) uint64 { return sh.Requests })
+	counter("ctrdrbg_generated_bytes_total", "Output bytes returned to callers.",
+		func(sh *ShardStats) uint64 { return sh.BytesGenerated })
+
+	const reseeds = "ctrdrbg_reseeds_total"
+	fmt.Fprintf(buf, "# HELP %s Successful reseeds by cause.\n# TYPE %s counter\n", reseeds, reseeds)
+	for i := range s.Shards {
+		r := &s.Shards[i].Reseeds
+		for _, c := range []struct {
+			cause string
+			n     uint64
+		}{
+			{"interval", r.Interval},
+			{"request_count", r.RequestCount},
+			{"prediction_resistance", r.PredictionResistance},
+			{"fork", r.Fork},
+			{"generation", r.Generation},
+			{"manual", r.Manual},
+			{"additional_input", r.AdditionalInput},
+			{"rekey_failure", r.RekeyFailure},
+		} {
+			fmt.Fprintf(buf, "%s{shard=\"%d\",cause=%q} %d\n", reseeds, i, c.cause, c.n)
+		}
+	}
+
+	counter("ctrdrbg_rekeys_total", "Successful key rotations.",
+		func(sh *ShardStats) uint64 { return sh.Rekeys })
+	counter("ctrdrbg_rekey_failures_total", "Key rotations that exhausted MaxRekeyAttempts.",
+		func(sh *ShardStats) uint64 { return sh.RekeyFailures })
+
+	const rekeyDuration = "ctrdrbg_rekey_duration_seconds"
+	fmt.Fprintf(buf, "# HELP %s Seed derivation time of successful key rotations.\n# TYPE %s histogram\n",
+		rekeyDuration, rekeyDuration)
+	for i := range s.Shards {
+		h := &s.Shards[i].RekeyLatency
+		var cumulative uint64
+		for j, bound := range latencyBounds {
+			cumulative += h.Buckets[j]
+			fmt.Fprintf(buf, "%s_bucket{shard=\"%d\",le=%q} %d\n", rekeyDuration, i,
+				strconv.FormatFloat(bound.Seconds(), 'g', -1, 64), cumulative)
+		}
+		cumulative += h.Buckets[len(latencyBounds)]
+		fmt.Fprintf(buf, "%s_bucket{shard=\"%d\",le=\"+Inf\"} %d\n", rekeyDuration, i, cumulative)
+		fmt.Fprintf(buf, "%s_sum{shard=\"%d\"} %s\n", rekeyDuration, i,
+			strconv.FormatFloat(h.Sum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(buf, "%s_count{shard=\"%d\"} %d\n", rekeyDuration, i, cumulative)
+	}
+
+	counter("ctrdrbg_health_test_failures_total", "Continuous health test failures.",
+		func(sh *ShardStats) uint64 { return sh.HealthTestFailures })
+	counter("ctrdrbg_entropy_bytes_total", "Entropy input bytes consumed.",
+		func(sh *ShardStats) uint64 { return sh.EntropyBytes })
+}
+
+var (
+	// expvarMu serializes checking and publishing expvar names, since expvar.Publish panics on duplicates,
+	// and guards expvarBindings.
+	expvarMu sync.Mutex
+
+	// expvarBindings holds the binding published under each name by publishExpvar. The expvar registry
+	// cannot remove entries, so a binding is reused when its name is published again.
+	expvarBindings = map[string]*expvarBinding{}
+)
+
+// expvarBinding is the expvar.Var published for a Reader.
+//
+// It refers to the Reader weakly, so a published name does not keep a Reader reachable, and Close unbinds
+// it. An unbound binding reports null and may be bound to a new Reader.
+type expvarBinding struct {
+	r atomic.Pointer[weak.Pointer[reader]]
+}
+
+// reader returns the bound Reader, or nil if the binding was unbound or the Reader garbage collected.
+func (b *expvarBinding) reader() *reader {
+	if w := b.r.Load(); w != nil {
+		return w.Value()
+	}
+	return nil
+}
+
+// String implements expvar.Var, returning the Reader's Stats as JSON, or null if it is no longer bound.
+func (b *expvarBinding) String() string {
+	r := b.reader()
+	if r == nil {
+		return "null"
+	}
+	v, err := json.Marshal(r.Stats())
+	if err != nil {
+		return "null"
+	}
+	return string(v)
+}
+
+// publishExpvar publishes the Stats of r under name in the expvar registry, until r is closed.
+//
+// Returns an error wrapping ErrExpvarNameInUse if name is published by another Reader that is still open,
+// or by anything other than a Reader.
+func publishExpvar(name string, r *reader) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	b, ok := expvarBindings[name]
+	switch {
+	case ok && b.reader() != nil, !ok && expvar.Get(name) != nil:
+		return fmt.Errorf("%w: %q", ErrExpvarNameInUse, name)
+	case !ok:
+		b = &expvarBinding{}
+		expvar.Publish(name, b)
+		expvarBindings[name] = b
+	}
+	w := weak.Make(r)
+	b.r.Store(&w)
+	r.expvar = b
+	return nil
+}
+
+// unpublishExpvar unbinds r from the name it was published under, if any.
+func unpublishExpvar(r *reader) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if b := r.expvar; b != nil && b.reader() == r {
+		b.r.Store(nil)
+	}
+	r.expvar = nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_MetricsHandler verifies the Prometheus text exposition served for a Reader.
+func Test_MetricsHandler(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(2), WithShardStrategy(ShardStrategyRoundRobin),
+		WithEnableKeyRotation(true), WithMaxBytesPerKey(64), WithRekeyMode(RekeyModeSync))
+	is.NoError(err)
+	defer r.Close()
+
+	buf := make([]byte, 64)
+	for i := 0; i < 4; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+	is.NoError(r.Reseed(nil))
+
+	rec := httptest.NewRecorder()
+	MetricsHandler(r).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	is.Equal(200, rec.Code)
+	is.Equal(metricsContentType, rec.Header().Get("Content-Type"))
+
+	body, err := io.ReadAll(rec.Body)
+	is.NoError(err)
+	text := string(body)
+
+	for _, line := range []string{
+		"# TYPE ctrdrbg_requests_total counter",
+		`ctrdrbg_requests_total{shard="0"} 2`,
+		`ctrdrbg_requests_total{shard="1"} 2`,
+		`ctrdrbg_generated_bytes_total{shard="1"} 128`,
+		`ctrdrbg_reseeds_total{shard="0",cause="manual"} 1`,
+		`ctrdrbg_reseeds_total{shard="1",cause="fork"} 0`,
+		`ctrdrbg_rekeys_total{shard="0"} 2`,
+		"# TYPE ctrdrbg_rekey_duration_seconds histogram",
+		`ctrdrbg_rekey_duration_seconds_bucket{shard="0",le="1e-05"}`,
+		`ctrdrbg_rekey_duration_seconds_bucket{shard="0",le="+Inf"} 2`,
+		`ctrdrbg_rekey_duration_seconds_count{shard="1"} 2`,
+		`ctrdrbg_health_test_failures_total{shard="0"} 0`,
+		"ctrdrbg_entropy_bytes_total{shard=\"1\"}",
+	} {
+		is.Contains(text, line)
+	}
+
+	// Every sample line is a metric name, optional labels, and a value.
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		is.True(strings.HasPrefix(line, "ctrdrbg_"), line)
+		is.Len(strings.Fields(line), 2, line)
+	}
+}
+
+// Test_WithExpvar verifies that a Reader's Stats are published to expvar, that a name cannot be published
+// by two open Readers, and that Close unbinds the name.
+func Test_WithExpvar(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	const name = "ctrdrbg_test_stats"
+	r, err := NewReader(WithShards(1), WithExpvar(name))
+	is.NoError(err)
+	_, err = r.Read(make([]byte, 32))
+	is.NoError(err)
+
+	v := expvar.Get(name)
+	is.NotNil(v)
+	var s Stats
+	is.NoError(json.Unmarshal([]byte(v.String()), &s))
+	is.Equal(r.Stats(), s)
+	is.Equal(uint64(1), s.Total.Requests)
+
+	_, err = NewReader(WithShards(1), WithExpvar(name))
+	is.ErrorIs(err, ErrExpvarNameInUse)
+
+	is.NoError(r.Close())
+	is.Equal("null", v.String(), "a closed Reader should no longer be reported")
+
+	r2, err := NewReader(WithShards(1), WithExpvar(name))
+	is.NoError(err, "the name should be reusable after Close")
+	defer r2.Close()
+	is.NoError(json.Unmarshal([]byte(expvar.Get(name).String()), &s))
+	is.Zero(s.Total.Requests)
+
+	if expvar.Get("ctrdrbg_test_foreign") == nil {
+		expvar.NewInt("ctrdrbg_test_foreign")
+	}
+	_, err = NewReader(WithShards(1), WithExpvar("ctrdrbg_test_foreign"))
+	is.ErrorIs(err, ErrExpvarNameInUse, "a name published by anything else should be refused")
+}
+
+// Test_WithExpvar_Unreachable verifies that a published name does not keep an unclosed Reader reachable.
+func Test_WithExpvar_Unreachable(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	const name = "ctrdrbg_test_unreachable"
+	_, err := NewReader(WithShards(1), WithExpvar(name))
+	is.NoError(err)
+
+	runtime.GC()
+	runtime.GC()
+	is.Equal("null", expvar.Get(name).String())
+}
+
+// Test_LatencyHistogram verifies bucket selection at and around the bounds.
+func Test_LatencyHistogram(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var c latencyCounts
+	c.observe(0)
+	c.observe(10 * time.Microsecond)
+	c.observe(11 * time.Microsecond)
+	c.observe(2 * time.Second)
+
+	var h LatencyHistogram
+	c.addTo(&h)
+	is.Equal(uint64(2), h.Buckets[0], "a duration equal to a bound belongs to that bucket")
+	is.Equal(uint64(1), h.Buckets[1])
+	is.Equal(uint64(1), h.Buckets[len(LatencyBounds())], "durations above every bound overflow")
+	is.Equal(uint64(4), h.Count())
+	is.Equal(2*time.Second+21*time.Microsecond, h.Sum)
+}
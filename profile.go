@@ -0,0 +1,146 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrProfileViolation is returned by Config.Validate (and so by NewReader) when an option applied after
+// WithProfile overrides a setting the selected Profile requires.
+var ErrProfileViolation = errors.New("ctrdrbg: configuration violates profile")
+
+// Profile is a named preset of options, selected with WithProfile, together with the constraints that keep
+// a configuration within it.
+type Profile int
+
+const (
+	// ProfileNone applies no preset and no constraints. It is the default.
+	ProfileNone Profile = iota
+
+	// ProfileFIPS140_3 configures the Reader for FIPS 140-3 aligned operation: AES-256, Known Answer Tests,
+	// zeroization, continuous health testing, fork detection on every request, a reseed every 2^20
+	// requests, and synchronous key rotation that fails closed.
+	//
+	// Overrides must keep self-tests, zeroization, and the continuous health test enabled, fork detection
+	// on every request, a nonzero ReseedRequests unless PredictionResistance is enabled, and, when key
+	// rotation is enabled, a RekeyFailurePolicy other than RekeyFailureContinue. Other settings, such as
+	// KeySize or Personalization, may be changed.
+	ProfileFIPS140_3
+
+	// ProfilePerformance configures the Reader for throughput: a 4 KiB keystream cache, per-processor shard
+	// affinity, and background key rotation. Security measures that do not cost throughput, such as fork
+	// detection on every request, are kept.
+	//
+	// Overrides must keep the keystream cache enabled and key rotation in RekeyModeAsync, and must not
+	// enable PredictionResistance or UseZeroBuffer.
+	ProfilePerformance
+)
+
+// fips140ReseedRequests is the reseed interval, in requests, set by ProfileFIPS140_3.
+const fips140ReseedRequests = 1 << 20
+
+// performanceKeystreamCacheSize is the keystream cache size set by ProfilePerformance.
+const performanceKeystreamCacheSize = 4096
+
+// String returns a human-readable name for the profile.
+func (p Profile) String() string {
+	switch p {
+	case ProfileNone:
+		return "none"
+	case ProfileFIPS140_3:
+		return "fips140-3"
+	case ProfilePerformance:
+		return "performance"
+	default:
+		return fmt.Sprintf("Profile(%d)", int(p))
+	}
+}
+
+// WithProfile returns an Option that applies the presets of p and records p in Config.Profile.
+//
+// Options applied after WithProfile may adjust the presets, but NewReader rejects, with an error wrapping
+// ErrProfileViolation, any that take the configuration outside the profile. Options applied before it are
+// overwritten by the presets.
+//
+// Example:
+//
+//	r, err := ctrdrbg.NewReader(
+//	    ctrdrbg.WithProfile(ctrdrbg.ProfileFIPS140_3),
+//	    ctrdrbg.WithPersonalization([]byte("service-A")),
+//	)
+func WithProfile(p Profile) Option {
+	return func(cfg *Config) {
+		cfg.Profile = p
+		switch p {
+		case ProfileFIPS140_3:
+			cfg.KeySize = KeySize256
+			cfg.EnableSelfTests = true
+			cfg.EnableZeroization = true
+			cfg.ContinuousHealthTest = true
+			cfg.ForkDetectionInterval = 0
+			cfg.ReseedRequests = fips140ReseedRequests
+			cfg.EnableKeyRotation = true
+			cfg.RekeyMode = RekeyModeSync
+			cfg.RekeyFailurePolicy = RekeyFailureFailClosed
+		case ProfilePerformance:
+			cfg.KeystreamCacheSize = performanceKeystreamCacheSize
+			cfg.ShardStrategy = ShardStrategyAffinity
+			cfg.RekeyMode = RekeyModeAsync
+			cfg.PredictionResistance = false
+			cfg.UseZeroBuffer = false
+		}
+	}
+}
+
+// validateProfile checks cfg against the constraints of cfg.Profile, returning one error per violation.
+func validateProfile(cfg *Config) []error {
+	p := cfg.Profile
+	var errs []error
+	violate := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s: "+format, append([]any{ErrProfileViolation, p}, args...)...))
+	}
+
+	switch p {
+	case ProfileNone:
+	case ProfileFIPS140_3:
+		if !cfg.EnableSelfTests {
+			violate("self-tests must be enabled")
+		}
+		if !cfg.EnableZeroization {
+			violate("zeroization must be enabled")
+		}
+		if !cfg.ContinuousHealthTest {
+			violate("the continuous health test must be enabled")
+		}
+		if cfg.ForkDetectionInterval != 0 {
+			violate("ForkDetectionInterval must be 0, not %d", cfg.ForkDetectionInterval)
+		}
+		if cfg.ReseedRequests == 0 && !cfg.PredictionResistance {
+			violate("ReseedRequests must be set unless prediction resistance is enabled")
+		}
+		if cfg.EnableKeyRotation && cfg.RekeyFailurePolicy == RekeyFailureContinue {
+			violate("RekeyFailurePolicy must not be %s", RekeyFailureContinue)
+		}
+	case ProfilePerformance:
+		if cfg.KeystreamCacheSize == 0 {
+			violate("the keystream cache must be enabled")
+		}
+		if cfg.RekeyMode != RekeyModeAsync {
+			violate("RekeyMode must be %s, not %s", RekeyModeAsync, cfg.RekeyMode)
+		}
+		if cfg.PredictionResistance {
+			violate("prediction resistance must be disabled")
+		}
+		if cfg.UseZeroBuffer {
+			violate("UseZeroBuffer must be disabled")
+		}
+	default:
+		errs = append(errs, fmt.Errorf("%w: unknown profile %s", ErrInvalidConfig, p))
+	}
+	return errs
+}
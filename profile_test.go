@@ -0,0 +1,83 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_Profile_FIPS140_3 verifies that the FIPS 140-3 profile produces a Reader with no failing compliance
+// requirements, accepts overrides that stay within it, and rejects those that do not.
+func Test_Profile_FIPS140_3(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithProfile(ProfileFIPS140_3), WithShards(1), WithPersonalization([]byte("svc")))
+	is.NoError(err)
+	defer r.Close()
+	for _, res := range r.ComplianceReport().Results {
+		is.NotEqual(ComplianceFail, res.Status, "%s: %s", res.ID, res.Detail)
+	}
+
+	_, err = NewReader(WithProfile(ProfileFIPS140_3), WithKeySize(KeySize128), WithShards(1))
+	is.NoError(err, "a smaller key size stays within the profile")
+
+	_, err = NewReader(WithProfile(ProfileFIPS140_3), WithZeroization(false), WithContinuousHealthTest(false),
+		WithForkDetectionInterval(100), WithRekeyFailurePolicy(RekeyFailureContinue))
+	is.ErrorIs(err, ErrProfileViolation)
+	var joined interface{ Unwrap() []error }
+	if is.ErrorAs(err, &joined) {
+		is.Len(joined.Unwrap(), 4)
+	}
+
+	_, err = NewReader(WithZeroization(false), WithProfile(ProfileFIPS140_3), WithShards(1))
+	is.NoError(err, "options before WithProfile are overwritten by its presets")
+
+	_, err = NewReader(WithProfile(ProfileFIPS140_3), WithReseedRequests(0))
+	is.ErrorIs(err, ErrProfileViolation, "the reseed counter must stay enforced")
+	_, err = NewReader(WithProfile(ProfileFIPS140_3), WithReseedRequests(0), WithPredictionResistance(true), WithShards(1))
+	is.NoError(err, "prediction resistance reseeds before every request")
+}
+
+// Test_Profile_Performance verifies the performance profile's presets and constraints.
+func Test_Profile_Performance(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithProfile(ProfilePerformance), WithShards(1))
+	is.NoError(err)
+	defer r.Close()
+	is.Equal(performanceKeystreamCacheSize, r.Config().KeystreamCacheSize)
+	is.Equal(ProfilePerformance, r.Config().Profile)
+
+	for _, opt := range []Option{
+		WithKeystreamCache(0),
+		WithRekeyMode(RekeyModeSync),
+		WithUseZeroBuffer(true),
+	} {
+		_, err = NewReader(WithProfile(ProfilePerformance), opt)
+		is.ErrorIs(err, ErrProfileViolation)
+	}
+
+	_, err = NewReader(WithProfile(ProfilePerformance), WithPredictionResistance(true))
+	is.ErrorIs(err, ErrProfileViolation)
+	is.ErrorIs(err, ErrInvalidConfig, "prediction resistance also conflicts with the keystream cache")
+}
+
+// Test_Profile_Unknown verifies that an unknown profile is rejected.
+func Test_Profile_Unknown(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	_, err := NewReader(WithProfile(Profile(9)))
+	is.ErrorIs(err, ErrInvalidConfig)
+	is.Equal("Profile(9)", Profile(9).String())
+	is.Equal("fips140-3", ProfileFIPS140_3.String())
+	is.Equal("performance", ProfilePerformance.String())
+	is.Equal("none", ProfileNone.String())
+}
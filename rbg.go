@@ -0,0 +1,276 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"crypto/fips140"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrReseedNotPermitted is returned when a reseed is requested from an RBG1 construction, which is
+	// seeded exactly once from an external RBG (NIST SP 800-90C §4).
+	ErrReseedNotPermitted = errors.New("ctrdrbg: reseed not permitted for RBG1 construction")
+
+	// ErrConstruction indicates that a configuration violates the requirements of its SP 800-90C construction.
+	ErrConstruction = errors.New("ctrdrbg: configuration violates SP 800-90C construction requirements")
+)
+
+// Construction identifies the NIST SP 800-90C Random Bit Generator construction in which the DRBG operates,
+// i.e., how the DRBG is combined with its randomness source.
+//
+// Each construction documents and enforces its required security strength and reseed behavior. NewReader
+// rejects configurations that violate the selected construction with an error wrapping ErrConstruction.
+//
+// Reference:
+//
+//	NIST Special Publication 800-90C, §4 (RBG Constructions)
+//	https://csrc.nist.gov/pubs/sp/800/90/c/final
+type Construction int
+
+const (
+	// ConstructionUnspecified does not claim conformance to any SP 800-90C construction and applies no
+	// construction-specific constraints. This is the default.
+	ConstructionUnspecified Construction = iota
+
+	// ConstructionRBG1 is seeded once from an external RBG and never reseeded (SP 800-90C §4.2).
+	//
+	// Requirements:
+	//   - The EntropySource must be classified as SourceKindRBG (an external RBG2 or RBG3).
+	//   - Prediction resistance, interval and request-count reseeding, and key rotation must be disabled.
+	//   - Reseed returns ErrReseedNotPermitted. Additional input is applied with the SP 800-90A §10.2.1.2
+	//     update function, without drawing new entropy.
+	//   - Security strength: 128, 192, or 256 bits, bounded by that of the external RBG.
+	//
+	// After a process fork or VM generation change, instances are re-instantiated from the external RBG,
+	// since continuing a duplicated state would repeat output.
+	ConstructionRBG1
+
+	// ConstructionRBG2P has a physical entropy source and supports reseeding (SP 800-90C §4.3).
+	//
+	// Requirements:
+	//   - The EntropySource must be classified as SourceKindPhysical.
+	//   - Security strength: 128, 192, or 256 bits.
+	ConstructionRBG2P
+
+	// ConstructionRBG2NP has a non-physical entropy source and supports reseeding (SP 800-90C §4.3).
+	//
+	// Requirements:
+	//   - The EntropySource must be classified as SourceKindNonPhysical (e.g., GetrandomSource).
+	//   - Security strength: 128, 192, or 256 bits.
+	ConstructionRBG2NP
+
+	// ConstructionRBG3XOR XORs fresh entropy source output with DRBG output for every request, providing
+	// full-entropy output (SP 800-90C §4.4).
+	//
+	// Requirements:
+	//   - The EntropySource must be classified as SourceKindPhysical or SourceKindNonPhysical; an RBG is
+	//     not an entropy source and cannot support an RBG3.
+	//   - Security strength: 256 bits (KeySize256).
+	//   - Every output request draws len(b) additional bytes from the entropy source.
+	ConstructionRBG3XOR
+)
+
+// String returns the SP 800-90C name of the construction.
+func (c Construction) String() string {
+	switch c {
+	case ConstructionUnspecified:
+		return "unspecified"
+	case ConstructionRBG1:
+		return "RBG1"
+	case ConstructionRBG2P:
+		return "RBG2(P)"
+	case ConstructionRBG2NP:
+		return "RBG2(NP)"
+	case ConstructionRBG3XOR:
+		return "RBG3(XOR)"
+	default:
+		return fmt.Sprintf("Construction(%d)", int(c))
+	}
+}
+
+// MinSecurityStrength returns the minimum DRBG security strength, in bits, required by the construction.
+func (c Construction) MinSecurityStrength() int {
+	if c == ConstructionRBG3XOR {
+		return 256
+	}
+	return 128
+}
+
+// SecurityStrength returns the security strength, in bits, of AES-CTR-DRBG with this key size
+// (SP 800-90A Table 3): 128, 192, or 256.
+func (k KeySize) SecurityStrength() int {
+	return int(k) * 8
+}
+
+// SourceKind classifies an entropy source for the purpose of SP 800-90C construction checks.
+type SourceKind int
+
+const (
+	// SourceKindUnknown is reported for sources that do not implement SourceClassifier.
+	SourceKindUnknown SourceKind = iota
+
+	// SourceKindPhysical is a physical entropy source (e.g., a hardware noise source).
+	SourceKindPhysical
+
+	// SourceKindNonPhysical is a non-physical entropy source (e.g., the operating system's entropy pool).
+	SourceKindNonPhysical
+
+	// SourceKindRBG is itself a random bit generator, such as crypto/rand in Go's FIPS-140 mode.
+	SourceKindRBG
+)
+
+// String returns a human-readable name for the source kind.
+func (k SourceKind) String() string {
+	switch k {
+	case SourceKindUnknown:
+		return "unknown"
+	case SourceKindPhysical:
+		return "physical"
+	case SourceKindNonPhysical:
+		return "non-physical"
+	case SourceKindRBG:
+		return "RBG"
+	default:
+		return fmt.Sprintf("SourceKind(%d)", int(k))
+	}
+}
+
+// SourceClassifier is an optional interface implemented by an EntropySource to declare its SourceKind.
+type SourceClassifier interface {
+	SourceKind() SourceKind
+}
+
+// sourceKind returns the SourceKind of src, or SourceKindUnknown if it does not implement SourceClassifier.
+func sourceKind(src EntropySource) SourceKind {
+	if c, ok := src.(SourceClassifier); ok {
+		return c.SourceKind()
+	}
+	return SourceKindUnknown
+}
+
+// SourceKind reports SourceKindRBG in Go's FIPS-140 mode, where crypto/rand.Reader is a DRBG, and
+// SourceKindNonPhysical otherwise.
+func (systemSource) SourceKind() SourceKind {
+	if fips140.Enabled() {
+		return SourceKindRBG
+	}
+	return SourceKindNonPhysical
+}
+
+// SourceKind reports SourceKindNonPhysical: the kernel entropy pool is a non-physical entropy source.
+func (s *GetrandomSource) SourceKind() SourceKind {
+	return SourceKindNonPhysical
+}
+
+// SourceKind reports the common kind of all member sources, or SourceKindUnknown if they differ.
+func (c *EntropyChain) SourceKind() SourceKind {
+	kind := sourceKind(c.members[0].Source)
+	for _, m := range c.members[1:] {
+		if sourceKind(m.Source) != kind {
+			return SourceKindUnknown
+		}
+	}
+	return kind
+}
+
+// validateConstruction checks cfg against the requirements of cfg.Construction.
+//
+// Returns an error wrapping ErrConstruction that names every violated requirement.
+func validateConstruction(cfg *Config) error {
+	c := cfg.Construction
+	if c == ConstructionUnspecified {
+		return nil
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s: "+format, append([]any{ErrConstruction, c}, args...)...))
+	}
+
+	if s := cfg.KeySize.SecurityStrength(); s < c.MinSecurityStrength() {
+		fail("security strength %d bits is below the required %d bits", s, c.MinSecurityStrength())
+	}
+
+	kind := sourceKind(cfg.entropySource())
+	switch c {
+	case ConstructionRBG1:
+		if kind != SourceKindRBG {
+			fail("entropy source %q is %s; RBG1 requires an external RBG", cfg.entropySource().Name(), kind)
+		}
+		if cfg.PredictionResistance {
+			fail("prediction resistance requires reseeding, which RBG1 does not permit")
+		}
+		if cfg.ReseedInterval > 0 || cfg.ReseedRequests > 0 {
+			fail("automatic reseeding is not permitted")
+		}
+		if cfg.EnableKeyRotation {
+			fail("key rotation reseeds from the randomness source, which RBG1 does not permit")
+		}
+	case ConstructionRBG2P:
+		if kind != SourceKindPhysical {
+			fail("entropy source %q is %s; RBG2(P) requires a physical entropy source", cfg.entropySource().Name(), kind)
+		}
+	case ConstructionRBG2NP:
+		if kind != SourceKindNonPhysical {
+			fail("entropy source %q is %s; RBG2(NP) requires a non-physical entropy source", cfg.entropySource().Name(), kind)
+		}
+	case ConstructionRBG3XOR:
+		if kind != SourceKindPhysical && kind != SourceKindNonPhysical {
+			fail("entropy source %q is %s; RBG3(XOR) requires a physical or non-physical entropy source", cfg.entropySource().Name(), kind)
+		}
+	default:
+		fail("unknown construction")
+	}
+
+	return errors.Join(errs...)
+}
+
+// xorEntropy implements the RBG3(XOR) output step: b ^= fresh entropy source output.
+//
+// The scratch buffer is retained on the instance and cleared after use.
+func (d *drbg) xorEntropy(b []byte) error {
+	if cap(d.xorBuf) < len(b) {
+		d.xorBuf = make([]byte, len(b))
+	}
+	buf := d.xorBuf[:len(b)]
+	defer clear(buf)
+
+	if _, err := readEntropy(d.config, buf); err != nil {
+		return fmt.Errorf("RBG3(XOR) entropy read failed: %w", err)
+	}
+	d.stats.entropyBytes.Add(uint64(len(buf)))
+	subtle.XORBytes(b, b, buf)
+	return nil
+}
+
+// update applies the SP 800-90A §10.2.1.2 CTR_DRBG_Update function with providedData, deriving a new
+// key and V from the current state without drawing new entropy.
+//
+// It is used to incorporate additional input in the RBG1 construction, where reseeding is not permitted.
+// providedData longer than seedlen is folded in by XOR. A successful update is recorded as AuditUpdate.
+func (d *drbg) update(providedData []byte) error {
+	seedLen := int(d.config.KeySize) + 16
+	temp := d.reseedBuf.seed[:seedLen]
+	defer d.reseedBuf.wipe()
+
+	v := d.v
+	for off := 0; off < seedLen; off += 16 {
+		incV(&v)
+		d.state.block.Encrypt(temp[off:off+16], v[:])
+	}
+	clear(v[:])
+
+	mixSeed(temp, nil, providedData)
+
+	if err := d.installSeed(temp); err != nil {
+		return err
+	}
+	d.auditEvent(AuditUpdate, "")
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// kindSource is a test EntropySource that declares a SourceKind and counts bytes read.
+type kindSource struct {
+	countingSource
+	kind SourceKind
+}
+
+func (s *kindSource) SourceKind() SourceKind { return s.kind }
+
+// Test_Construction_Validate verifies that NewReader enforces the requirements of each construction.
+func Test_Construction_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		opts []Option
+		ok   bool
+	}{
+		{"Unspecified", []Option{WithEntropySource(&kindSource{kind: SourceKindUnknown})}, true},
+		{"RBG1", []Option{WithConstruction(ConstructionRBG1), WithEntropySource(&kindSource{kind: SourceKindRBG})}, true},
+		{"RBG1_EntropySource", []Option{WithConstruction(ConstructionRBG1), WithEntropySource(&kindSource{kind: SourceKindPhysical})}, false},
+		{"RBG1_PredictionResistance", []Option{WithConstruction(ConstructionRBG1), WithEntropySource(&kindSource{kind: SourceKindRBG}), WithPredictionResistance(true)}, false},
+		{"RBG1_KeyRotation", []Option{WithConstruction(ConstructionRBG1), WithEntropySource(&kindSource{kind: SourceKindRBG}), WithEnableKeyRotation(true)}, false},
+		{"RBG1_ReseedRequests", []Option{WithConstruction(ConstructionRBG1), WithEntropySource(&kindSource{kind: SourceKindRBG}), WithReseedRequests(10)}, false},
+		{"RBG2P", []Option{WithConstruction(ConstructionRBG2P), WithEntropySource(&kindSource{kind: SourceKindPhysical})}, true},
+		{"RBG2P_NonPhysical", []Option{WithConstruction(ConstructionRBG2P), WithEntropySource(&kindSource{kind: SourceKindNonPhysical})}, false},
+		{"RBG2NP", []Option{WithConstruction(ConstructionRBG2NP), WithEntropySource(&kindSource{kind: SourceKindNonPhysical})}, true},
+		{"RBG2NP_Unknown", []Option{WithConstruction(ConstructionRBG2NP), WithEntropySource(&kindSource{kind: SourceKindUnknown})}, false},
+		{"RBG3XOR", []Option{WithConstruction(ConstructionRBG3XOR), WithEntropySource(&kindSource{kind: SourceKindPhysical})}, true},
+		{"RBG3XOR_AES128", []Option{WithConstruction(ConstructionRBG3XOR), WithEntropySource(&kindSource{kind: SourceKindPhysical}), WithKeySize(KeySize128)}, false},
+		{"RBG3XOR_RBGSource", []Option{WithConstruction(ConstructionRBG3XOR), WithEntropySource(&kindSource{kind: SourceKindRBG})}, false},
+		{"Unknown", []Option{WithConstruction(Construction(99))}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			_, err := NewReader(append(tc.opts, WithShards(1))...)
+			if tc.ok {
+				is.NoError(err)
+			} else {
+				is.ErrorIs(err, ErrConstruction)
+			}
+		})
+	}
+}
+
+// Test_Construction_RBG1 verifies that RBG1 refuses reseeding and applies additional input without new entropy.
+func Test_Construction_RBG1(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &kindSource{kind: SourceKindRBG}
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	cfg.Construction = ConstructionRBG1
+
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+	is.ErrorIs(d.Reseed(nil), ErrReseedNotPermitted)
+
+	consumed := src.bytes.Load()
+	before := d.state.key
+
+	buf := make([]byte, 32)
+	_, err = d.ReadWithAdditionalInput(buf, []byte("additional input"))
+	is.NoError(err)
+	is.NotEqual(before, d.state.key, "additional input should update the key")
+	is.Equal(consumed, src.bytes.Load(), "RBG1 update must not draw from the source")
+
+	rdr, err := NewReader(WithConstruction(ConstructionRBG1), WithEntropySource(src), WithShards(1))
+	is.NoError(err)
+	is.ErrorIs(rdr.Reseed(nil), ErrReseedNotPermitted)
+	_, err = rdr.Read(buf)
+	is.NoError(err, "a refused Reseed should not block output")
+}
+
+// Test_Construction_RBG1_Update verifies that the update function is deterministic in its inputs.
+func Test_Construction_RBG1_Update(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Construction = ConstructionRBG1
+	d1, err := newDRBG(&cfg)
+	is.NoError(err)
+	d2, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	// Give both instances an identical state, then apply identical additional input.
+	cloneState(t, d2, d1)
+	is.NoError(d1.update([]byte("x")))
+	is.NoError(d2.update([]byte("x")))
+
+	out1 := make([]byte, 64)
+	out2 := make([]byte, 64)
+	_, _ = d1.Read(out1)
+	_, _ = d2.Read(out2)
+	is.Equal(out1, out2)
+}
+
+// Test_Construction_RBG3XOR verifies that RBG3(XOR) draws len(b) fresh entropy bytes per request.
+func Test_Construction_RBG3XOR(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &kindSource{kind: SourceKindNonPhysical}
+	_, err := NewReader(WithConstruction(ConstructionRBG3XOR), WithEntropySource(src), WithShards(1))
+	is.NoError(err)
+
+	// Measure a single instance, since a sync.Pool may construct additional instances at any time.
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	cfg.Construction = ConstructionRBG3XOR
+	rdr, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	before := src.bytes.Load()
+	buf := make([]byte, 100)
+	n, err := rdr.Read(buf)
+	is.NoError(err)
+	is.Equal(100, n)
+	is.Equal(before+100, src.bytes.Load(), "each output byte should consume one entropy byte")
+	is.False(bytes.Equal(buf, make([]byte, 100)))
+
+	before = src.bytes.Load()
+	_, err = rdr.ReadWithAdditionalInput(buf[:10], nil)
+	is.NoError(err)
+	is.Equal(before+10, src.bytes.Load())
+}
+
+// Test_Construction_String verifies construction, source kind, and security strength reporting.
+func Test_Construction_String(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal("unspecified", ConstructionUnspecified.String())
+	is.Equal("RBG1", ConstructionRBG1.String())
+	is.Equal("RBG2(P)", ConstructionRBG2P.String())
+	is.Equal("RBG2(NP)", ConstructionRBG2NP.String())
+	is.Equal("RBG3(XOR)", ConstructionRBG3XOR.String())
+	is.Equal("Construction(9)", Construction(9).String())
+	is.Equal("physical", SourceKindPhysical.String())
+
+	is.Equal(128, KeySize128.SecurityStrength())
+	is.Equal(192, KeySize192.SecurityStrength())
+	is.Equal(256, KeySize256.SecurityStrength())
+	is.Equal(256, ConstructionRBG3XOR.MinSecurityStrength())
+	is.Equal(128, ConstructionRBG2NP.MinSecurityStrength())
+}
@@ -0,0 +1,350 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RekeyMode selects how an instance rotates its key once MaxBytesPerKey bytes have been generated
+// under it (see EnableKeyRotation).
+type RekeyMode int
+
+const (
+	// RekeyModeAsync prepares the new key in a background goroutine and installs it at a later request.
+	// This is the default.
+	//
+	// Requests never wait for entropy, but output continues under the old key until the rekey completes,
+	// so MaxBytesPerKey is a soft limit.
+	RekeyModeAsync RekeyMode = iota
+
+	// RekeyModeSync rotates the key before returning from the request that reaches MaxBytesPerKey.
+	//
+	// That request blocks for the rekey, including any retries and backoff. At most MaxBytesPerKey bytes
+	// plus one request (less than MaxBytesPerRequest) are generated under a key.
+	RekeyModeSync
+
+	// RekeyModeInline rotates the key before generating a request that would exceed MaxBytesPerKey.
+	//
+	// That request blocks for the rekey, including any retries and backoff. At most MaxBytesPerKey bytes
+	// are generated under a key, except that a single request larger than MaxBytesPerKey is generated
+	// under a fresh key.
+	RekeyModeInline
+)
+
+// String returns a human-readable name for the rekey mode.
+func (m RekeyMode) String() string {
+	switch m {
+	case RekeyModeAsync:
+		return "async"
+	case RekeyModeSync:
+		return "sync"
+	case RekeyModeInline:
+		return "inline"
+	default:
+		return fmt.Sprintf("RekeyMode(%d)", int(m))
+	}
+}
+
+var (
+	// ErrRekeyFailed is the sentinel matched (via errors.Is) by *RekeyError.
+	ErrRekeyFailed = errors.New("ctrdrbg: key rotation failed")
+
+	// errNoRekeyAttempts is reported when MaxRekeyAttempts permits no attempt at all.
+	errNoRekeyAttempts = errors.New("MaxRekeyAttempts is zero")
+)
+
+// RekeyFailurePolicy selects what an instance does when automatic key rotation exhausts its
+// MaxRekeyAttempts, leaving a key that has already generated MaxBytesPerKey bytes.
+type RekeyFailurePolicy int
+
+const (
+	// RekeyFailureContinue keeps generating output under the exhausted key and retries the rotation
+	// later. This is the default and the historical behavior.
+	RekeyFailureContinue RekeyFailurePolicy = iota
+
+	// RekeyFailureFailClosed refuses output with a *RekeyError until a new key is installed. Each refused
+	// request retries the rotation (synchronously in RekeyModeSync and RekeyModeInline, in the background
+	// in RekeyModeAsync), and an explicit Reseed also resolves the failure.
+	RekeyFailureFailClosed
+
+	// RekeyFailureReseed falls back to a synchronous reseed from the entropy source in the next request.
+	// If that also fails, the request fails with a *RekeyError.
+	RekeyFailureReseed
+)
+
+// String returns a human-readable name for the rekey failure policy.
+func (p RekeyFailurePolicy) String() string {
+	switch p {
+	case RekeyFailureContinue:
+		return "continue"
+	case RekeyFailureFailClosed:
+		return "fail-closed"
+	case RekeyFailureReseed:
+		return "reseed"
+	default:
+		return fmt.Sprintf("RekeyFailurePolicy(%d)", int(p))
+	}
+}
+
+// RekeyError reports that automatic key rotation exhausted its attempts.
+//
+// It is passed to Config.RekeyFailureHandler under every policy, and returned by output requests under
+// RekeyFailureFailClosed and RekeyFailureReseed. It matches ErrRekeyFailed and the underlying cause via
+// errors.Is.
+type RekeyError struct {
+	// Mode is the rekey mode in which the rotation failed.
+	Mode RekeyMode
+
+	// Attempts is the number of rotation attempts made (MaxRekeyAttempts).
+	Attempts int
+
+	// Usage is the number of bytes generated under the current key when the failure was detected.
+	Usage uint64
+
+	// Err is the failure of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RekeyError) Error() string {
+	return fmt.Sprintf("ctrdrbg: %s key rotation failed after %d attempts with %d bytes generated under the current key: %v",
+		e.Mode, e.Attempts, e.Usage, e.Err)
+}
+
+// Unwrap returns ErrRekeyFailed and the underlying cause so callers can match either with errors.Is.
+func (e *RekeyError) Unwrap() []error {
+	return []error{ErrRekeyFailed, e.Err}
+}
+
+// Background rekey results, stored in drbg.rekeyResult.
+const (
+	// rekeyPending indicates the rekey goroutine is still preparing seed material.
+	rekeyPending uint32 = iota
+
+	// rekeyReady indicates a new seed is available in rekeyBuf for the owner to install.
+	rekeyReady
+
+	// rekeyFailed indicates every rekey attempt failed; the current state is retained.
+	rekeyFailed
+)
+
+// trackUsage adds generated to the per-key usage counter and, once MaxBytesPerKey is reached, rotates
+// the key according to the configured RekeyMode.
+func (d *drbg) trackUsage(generated int) {
+	d.usage += uint64(generated)
+	if d.usage < d.config.MaxBytesPerKey {
+		return
+	}
+
+	switch d.config.RekeyMode {
+	case RekeyModeSync:
+		if err := d.rekeySync(); err != nil {
+			d.rekeyFailed(err) // Enforced from the next request.
+		}
+	case RekeyModeInline:
+		// The next request rotates the key before it generates output (see rekeyBeforeGenerate).
+	default:
+		// At most one background rekey is in flight.
+		if !d.rekeying {
+			d.startRekey()
+		}
+	}
+}
+
+// rekeyIfDue runs before a request of n bytes generates output. In RekeyModeInline it rotates the key if
+// the request would take the current key past MaxBytesPerKey; a fresh key (zero usage) is never rotated.
+// It then enforces RekeyFailurePolicy for an unresolved rotation failure.
+//
+// Returns a *RekeyError if the request must not generate output.
+func (d *drbg) rekeyIfDue(n int) error {
+	if d.config.RekeyMode == RekeyModeInline && d.usage > 0 &&
+		d.usage+uint64(d.keystreamNeeded(n)) > d.config.MaxBytesPerKey {
+		if err := d.rekeySync(); err != nil {
+			d.rekeyFailed(err)
+		}
+	}
+
+	if d.rekeyErr == nil {
+		return nil
+	}
+	return d.enforceRekeyPolicy()
+}
+
+// rekeyFailed records a key rotation that exhausted its attempts: it notifies the RekeyFailureHandler,
+// if any, and retains the failure for enforcement unless the policy is RekeyFailureContinue.
+func (d *drbg) rekeyFailed(err error) {
+	d.stats.rekeyFailures.Add(1)
+	re := &RekeyError{Mode: d.config.RekeyMode, Attempts: d.config.MaxRekeyAttempts, Usage: d.usage, Err: err}
+	d.rekeyFailureEvent(re)
+	if d.config.RekeyFailureHandler != nil {
+		d.config.RekeyFailureHandler(re)
+	}
+	if d.config.RekeyFailurePolicy != RekeyFailureContinue {
+		d.rekeyErr = re
+	}
+}
+
+// enforceRekeyPolicy applies RekeyFailurePolicy to the unresolved failure in rekeyErr.
+//
+// Returns nil once a new key has been installed, or the *RekeyError if the request must be refused.
+func (d *drbg) enforceRekeyPolicy() error {
+	switch d.config.RekeyFailurePolicy {
+	case RekeyFailureReseed:
+		if err := d.reseed(ReseedCauseRekeyFailure, nil); err != nil {
+			d.rekeyFailed(err)
+			return d.rekeyErr
+		}
+	default: // RekeyFailureFailClosed
+		if d.config.RekeyMode == RekeyModeAsync {
+			// Retry in the background; the new key is applied at a later request.
+			if !d.rekeying {
+				d.startRekey()
+			}
+			return d.rekeyErr
+		}
+		if err := d.rekeySync(); err != nil {
+			d.rekeyFailed(err)
+			return d.rekeyErr
+		}
+	}
+	return nil
+}
+
+// keystreamNeeded returns the number of keystream bytes a request of n bytes will generate: n, or for
+// requests served from the keystream cache, the cache size if a refill is needed and zero otherwise.
+func (d *drbg) keystreamNeeded(n int) int {
+	if !d.cacheEnabled(n) {
+		return n
+	}
+	if len(d.cache)-d.cacheOff >= n {
+		return 0
+	}
+	return cap(d.cache)
+}
+
+// rekeySync rotates the key on the owning goroutine, blocking through retries and backoff.
+//
+// The new state is installed exactly as a reseed would install it, except that the reseed metadata
+// (lastReseedTime and the request count) is left unchanged. If every attempt fails, the current state
+// is retained and the last error is returned.
+func (d *drbg) rekeySync() error {
+	start := time.Now()
+	seed, err := d.deriveRekeySeed(&d.reseedBuf)
+	defer d.reseedBuf.wipe()
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+	if err := d.installSeed(seed); err != nil {
+		return err
+	}
+	d.seedSource = d.reseedBuf.source
+	d.stats.rekeys.Add(1)
+	d.stats.rekeyLatency.observe(elapsed)
+	d.rekeyEvent(elapsed)
+	return nil
+}
+
+// deriveRekeySeed derives a new seed into buf, making up to MaxRekeyAttempts attempts with exponential
+// backoff (starting at RekeyBackoff and bounded by MaxRekeyBackoff) between them.
+//
+// Returns the seed on success, or the last attempt's failure.
+func (d *drbg) deriveRekeySeed(buf *seedScratch) ([]byte, error) {
+	base := d.config.RekeyBackoff
+	maxBackoff := d.config.MaxRekeyBackoff
+	if maxBackoff == 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	err := errNoRekeyAttempts
+	for i := 0; i < d.config.MaxRekeyAttempts; i++ {
+		if i > 0 {
+			// Wait with exponential backoff before retrying.
+			time.Sleep(base)
+			base *= 2
+			if base > maxBackoff {
+				base = maxBackoff
+			}
+		}
+
+		// Obtain new entropy for key and counter (V), applying the personalization string.
+		var seed []byte
+		if seed, err = d.deriveSeed(buf, nil); err == nil {
+			return seed, nil
+		}
+		buf.wipe()
+	}
+	return nil, err
+}
+
+// startRekey starts preparing a new key in a background goroutine (RekeyModeAsync). The owner must not
+// already have a rekey in flight.
+func (d *drbg) startRekey() {
+	d.rekeying = true
+	d.rekeyResult.Store(rekeyPending)
+	d.rekeyWG.Add(1)
+	go d.asyncRekey()
+}
+
+// applyRekey completes a background rekey on the owning goroutine: once asyncRekey reports a prepared
+// seed, it is installed exactly as a reseed would install it, and the seed buffer is wiped.
+//
+// It is called at the start of every output request and costs a single branch unless a rekey is in
+// flight. A rekey still in progress is left pending, and output continues under the current key.
+func (d *drbg) applyRekey() {
+	if !d.rekeying {
+		return
+	}
+	switch d.rekeyResult.Load() {
+	case rekeyReady:
+		// Install the new AES key, counter (V), and cipher, zeroizing the old key material if enabled.
+		// If this fails, usage remains above MaxBytesPerKey and the next request starts a new rekey.
+		if d.installSeed(d.rekeyBuf.seed[:d.config.KeySize+16]) == nil {
+			d.seedSource = d.rekeyBuf.source
+			d.stats.rekeys.Add(1)
+			d.stats.rekeyLatency.observe(d.rekeyElapsed)
+			d.rekeyEvent(d.rekeyElapsed)
+		}
+		d.rekeyBuf.wipe()
+	case rekeyFailed:
+		// All retries failed: the generator keeps the prior state, subject to RekeyFailurePolicy.
+		d.rekeyFailed(d.rekeyAsyncErr)
+		d.rekeyAsyncErr = nil
+	default:
+		return // Still preparing.
+	}
+	d.rekeying = false
+}
+
+// asyncRekey prepares seed material for key rotation in the background, without blocking the owner.
+//
+// This function is launched by startRekey when the generated output reaches the configured threshold
+// (MaxBytesPerKey). It acquires fresh entropy into rekeyBuf and publishes the result through rekeyResult;
+// the owner installs the new key and counter at its next request (see applyRekey). asyncRekey never
+// touches the instance's cryptographic state, so the owner's generate path needs no locks.
+//
+// Steps:
+//  1. Attempt up to MaxRekeyAttempts seed derivations, with exponential backoff (bounded by MaxRekeyBackoff).
+//  2. For each attempt, acquire a fresh random seed and apply the personalization string.
+//  3. On success, publish rekeyReady; rekeyBuf then belongs to the owner. If every attempt fails, record
+//     the error and publish rekeyFailed; the owner then applies RekeyFailurePolicy.
+//
+// Parameters: None (method receiver only).
+func (d *drbg) asyncRekey() {
+	defer d.rekeyWG.Done()
+
+	start := time.Now()
+	if _, err := d.deriveRekeySeed(&d.rekeyBuf); err != nil {
+		d.rekeyAsyncErr = err
+		d.rekeyResult.Store(rekeyFailed)
+		return
+	}
+	d.rekeyElapsed = time.Since(start)
+	d.rekeyResult.Store(rekeyReady) // Hand the seed to the owner.
+}
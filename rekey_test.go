@@ -0,0 +1,299 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_RekeyMode_Bound verifies the bytes generated under each key for the synchronous modes: Sync
+// rotates before the crossing request returns, and Inline never lets a key exceed MaxBytesPerKey.
+func Test_RekeyMode_Bound(t *testing.T) {
+	t.Parallel()
+
+	const maxBytes = 1000
+
+	testCases := []struct {
+		name      string
+		mode      RekeyMode
+		cacheSize int
+		readSize  int
+		bound     uint64
+	}{
+		{"Sync", RekeyModeSync, 0, 96, maxBytes - 1},
+		{"Inline", RekeyModeInline, 0, 96, maxBytes},
+		{"InlineCached", RekeyModeInline, 256, 16, maxBytes},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			cfg := DefaultConfig()
+			cfg.EnableKeyRotation = true
+			cfg.MaxBytesPerKey = maxBytes
+			cfg.RekeyMode = tc.mode
+			cfg.KeystreamCacheSize = tc.cacheSize
+			d, err := newDRBG(&cfg)
+			is.NoError(err)
+			if tc.cacheSize > 0 {
+				d.cache = make([]byte, 0, tc.cacheSize)
+			}
+
+			rotations := 0
+			key := d.state.key
+			buf := make([]byte, tc.readSize)
+			for i := 0; i < 200; i++ {
+				_, err = d.Read(buf)
+				is.NoError(err)
+				is.LessOrEqual(d.usage, tc.bound, "usage under the current key should stay bounded")
+				if d.state.key != key {
+					key = d.state.key
+					rotations++
+				}
+			}
+			is.False(d.rekeying, "synchronous modes never start a background rekey")
+			is.Greater(rotations, 2, "the key should have been rotated repeatedly")
+		})
+	}
+}
+
+// Test_RekeyMode_Async verifies that the asynchronous mode keeps generating under the old key until the
+// background rekey is applied.
+func Test_RekeyMode_Async(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.EnableKeyRotation = true
+	cfg.MaxBytesPerKey = 64
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	key := d.state.key
+	buf := make([]byte, 128)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	is.Equal(key, d.state.key, "the crossing request should return under the old key")
+	is.True(d.rekeying)
+
+	awaitRekey(t, d)
+	is.NotEqual(key, d.state.key)
+}
+
+// Test_RekeyMode_Blocks verifies that a synchronous rekey blocks the crossing request through backoff
+// and, once every attempt fails, leaves the current key in place.
+func Test_RekeyMode_Blocks(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := &toggleSource{}
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	cfg.EnableKeyRotation = true
+	cfg.MaxBytesPerKey = 64
+	cfg.RekeyMode = RekeyModeSync
+	cfg.MaxRekeyAttempts = 3
+	cfg.RekeyBackoff = 10 * time.Millisecond
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	key := d.state.key
+	src.fail.Store(true)
+	start := time.Now()
+	_, err = d.Read(make([]byte, 64))
+	is.NoError(err)
+	is.GreaterOrEqual(time.Since(start), 30*time.Millisecond, "the request should wait through two backoffs")
+	is.Equal(key, d.state.key)
+
+	src.fail.Store(false)
+	_, err = d.Read(make([]byte, 16))
+	is.NoError(err)
+	is.NotEqual(key, d.state.key, "the next request should retry the rotation")
+}
+
+// newFailingRekeyDRBG returns an instance whose next key rotation is due, with its entropy source failing
+// and failures counted by the RekeyFailureHandler.
+func newFailingRekeyDRBG(t *testing.T, mode RekeyMode, policy RekeyFailurePolicy) (*drbg, *toggleSource, *atomic.Int32) {
+	t.Helper()
+
+	src := &toggleSource{}
+	failures := &atomic.Int32{}
+	cfg := DefaultConfig()
+	cfg.EntropySource = src
+	cfg.EnableKeyRotation = true
+	cfg.MaxBytesPerKey = 64
+	cfg.MaxRekeyAttempts = 2
+	cfg.RekeyBackoff = time.Millisecond
+	cfg.RekeyMode = mode
+	cfg.RekeyFailurePolicy = policy
+	cfg.RekeyFailureHandler = func(err *RekeyError) {
+		if errors.Is(err, ErrRekeyFailed) && errors.Is(err, errToggleSource) {
+			failures.Add(1)
+		}
+	}
+	d, err := newDRBG(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.fail.Store(true)
+	return d, src, failures
+}
+
+// Test_RekeyFailurePolicy verifies each policy's behavior once a synchronous rotation exhausts its attempts.
+func Test_RekeyFailurePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		policy  RekeyFailurePolicy
+		refuses bool
+	}{
+		{"Continue", RekeyFailureContinue, false},
+		{"FailClosed", RekeyFailureFailClosed, true},
+		{"Reseed", RekeyFailureReseed, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			d, src, failures := newFailingRekeyDRBG(t, RekeyModeSync, tc.policy)
+			key := d.state.key
+
+			// The crossing request is within the bound and succeeds; its rotation fails.
+			buf := make([]byte, 64)
+			n, err := d.Read(buf)
+			is.NoError(err)
+			is.Equal(64, n)
+			is.Equal(int32(1), failures.Load(), "the handler should be notified under every policy")
+
+			n, err = d.Read(buf[:16])
+			if tc.refuses {
+				var re *RekeyError
+				is.ErrorAs(err, &re)
+				is.ErrorIs(err, ErrRekeyFailed)
+				is.Equal(uint64(64), re.Usage)
+				is.Equal(RekeyModeSync, re.Mode)
+				is.Zero(n)
+			} else {
+				is.NoError(err)
+				is.Equal(16, n)
+			}
+			is.Equal(key, d.state.key, "no new key can be installed while the source fails")
+
+			// Once the source recovers, the next request installs a new key.
+			src.fail.Store(false)
+			_, err = d.Read(buf[:16])
+			is.NoError(err)
+			is.NotEqual(key, d.state.key)
+			is.Nil(d.rekeyErr)
+			if tc.policy == RekeyFailureReseed {
+				is.Equal(uint64(1), d.requests, "the fallback should be a full reseed")
+			}
+		})
+	}
+}
+
+// Test_RekeyFailurePolicy_Async verifies that a failed background rotation fails closed at the owner's next
+// request and is retried in the background.
+func Test_RekeyFailurePolicy_Async(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	d, src, failures := newFailingRekeyDRBG(t, RekeyModeAsync, RekeyFailureFailClosed)
+	key := d.state.key
+
+	buf := make([]byte, 64)
+	_, err := d.Read(buf)
+	is.NoError(err)
+	awaitRekeyResult(t, d)
+
+	_, err = d.Read(buf)
+	is.ErrorIs(err, ErrRekeyFailed)
+	is.Equal(int32(1), failures.Load())
+	is.True(d.rekeying, "the refused request should start another background rotation")
+
+	src.fail.Store(false)
+	for d.rekeying {
+		awaitRekeyResult(t, d)
+		_, err = d.Read(buf[:16])
+	}
+	is.NoError(err)
+	is.NotEqual(key, d.state.key)
+}
+
+// Test_RekeyFailurePolicy_Resolved verifies that an explicit Reseed resolves a fail-closed rotation failure.
+func Test_RekeyFailurePolicy_Resolved(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	d, src, _ := newFailingRekeyDRBG(t, RekeyModeInline, RekeyFailureFailClosed)
+	buf := make([]byte, 64)
+	_, err := d.Read(buf)
+	is.NoError(err)
+	_, err = d.Read(buf)
+	is.ErrorIs(err, ErrRekeyFailed, "inline mode should refuse before generating under the exhausted key")
+
+	src.fail.Store(false)
+	is.NoError(d.Reseed(nil))
+	is.Nil(d.rekeyErr)
+	_, err = d.Read(buf)
+	is.NoError(err)
+}
+
+// awaitRekeyResult waits until the background rekey started by d has finished, successfully or not.
+func awaitRekeyResult(t *testing.T, d *drbg) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for d.rekeyResult.Load() == rekeyPending {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for asyncRekey to finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Test_RekeyMode_Validate verifies that NewReader rejects unknown rekey modes.
+func Test_RekeyMode_Validate(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	for _, m := range []RekeyMode{RekeyModeAsync, RekeyModeSync, RekeyModeInline} {
+		_, err := NewReader(WithRekeyMode(m), WithEnableKeyRotation(true))
+		is.NoError(err, m.String())
+	}
+	_, err := NewReader(WithRekeyMode(RekeyMode(42)))
+	is.Error(err)
+	_, err = NewReader(WithRekeyFailurePolicy(RekeyFailurePolicy(42)))
+	is.Error(err)
+}
+
+// Test_RekeyMode_String verifies the rekey mode names.
+func Test_RekeyMode_String(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal("async", RekeyModeAsync.String())
+	is.Equal("sync", RekeyModeSync.String())
+	is.Equal("inline", RekeyModeInline.String())
+	is.Equal("RekeyMode(9)", RekeyMode(9).String())
+
+	is.Equal("continue", RekeyFailureContinue.String())
+	is.Equal("fail-closed", RekeyFailureFailClosed.String())
+	is.Equal("reseed", RekeyFailureReseed.String())
+	is.Equal("RekeyFailurePolicy(9)", RekeyFailurePolicy(9).String())
+}
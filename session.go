@@ -0,0 +1,163 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// ErrSessionReleased is returned when a Session is used after Release.
+var ErrSessionReleased = errors.New("ctrdrbg: session used after release")
+
+// Session holds exclusive access to one DRBG instance of a Reader until Release is called.
+//
+// A Session avoids the per-call shard selection and pool round trip of Reader.Read, which makes it
+// suitable for code that issues many consecutive reads, such as building a key bundle. All reads in a
+// session are served by the same instance, in order.
+//
+// A Session is not safe for concurrent use. Release returns the instance to its shard; it is idempotent,
+// so it may be deferred immediately after Acquire. Any read after Release fails with ErrSessionReleased.
+//
+// Acquire returns a Session by value, so acquiring one does not allocate. Keep it in one variable and
+// call its methods there: a copy refers to the same instance, and Release invalidates only the copy it
+// is called on.
+//
+// Example:
+//
+//	s, err := r.Acquire()
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer s.Release()
+//
+//	key, err := s.Bytes(32)
+//	if err != nil {
+//	    // handle error
+//	}
+type Session struct {
+	d    *drbg
+	pool *instancePool
+
+	// scratch holds the output of the typed helpers.
+	scratch [8]byte
+}
+
+// Acquire borrows a DRBG instance from the shard selected by the reader's ShardStrategy and returns a
+// Session holding it. The caller must call Release when done.
+//
+// Returns an error if a new instance is needed and cannot be instantiated.
+func (r *reader) Acquire() (Session, error) {
+	pool := r.pools[r.shard()]
+	d, err := pool.get()
+	if err != nil {
+		return Session{}, err
+	}
+	return Session{d: d, pool: pool}, nil
+}
+
+// Release returns the session's instance to its shard, or uninstantiates it if the Reader has been closed.
+// Calling Release more than once is a no-op.
+func (s *Session) Release() {
+	if s.d == nil {
+		return
+	}
+	s.pool.put(s.d)
+	s.d = nil
+	s.pool = nil
+	clear(s.scratch[:])
+}
+
+// Read fills b with cryptographically secure random bytes from the session's instance.
+//
+// It has the same semantics as Reader.Read, and returns ErrSessionReleased after Release and ErrClosed
+// after the Reader is closed.
+func (s *Session) Read(b []byte) (int, error) {
+	if s.d == nil {
+		return 0, ErrSessionReleased
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if s.pool.closed.Load() {
+		return 0, ErrClosed
+	}
+	// Apply any Reader.Reseed issued since the session was acquired.
+	if err := s.pool.refresh(s.d); err != nil {
+		return 0, err
+	}
+	return s.d.Read(b)
+}
+
+// ReadWithAdditionalInput fills b with cryptographically secure random bytes from the session's instance,
+// mixing in additionalInput per NIST SP 800-90A.
+//
+// It has the same semantics as Interface.ReadWithAdditionalInput, and returns ErrSessionReleased after
+// Release and ErrClosed after the Reader is closed.
+func (s *Session) ReadWithAdditionalInput(b []byte, additionalInput []byte) (int, error) {
+	if s.d == nil {
+		return 0, ErrSessionReleased
+	}
+	if s.pool.closed.Load() {
+		return 0, ErrClosed
+	}
+	if err := s.pool.refresh(s.d); err != nil {
+		return 0, err
+	}
+	return s.d.ReadWithAdditionalInput(b, additionalInput)
+}
+
+// Bytes returns n newly allocated cryptographically secure random bytes.
+//
+// n must not exceed MaxBytesPerRequest.
+func (s *Session) Bytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := s.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Uint32 returns a uniformly distributed random uint32.
+func (s *Session) Uint32() (uint32, error) {
+	if _, err := s.Read(s.scratch[:4]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(s.scratch[:4]), nil
+}
+
+// Uint64 returns a uniformly distributed random uint64.
+func (s *Session) Uint64() (uint64, error) {
+	if _, err := s.Read(s.scratch[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(s.scratch[:]), nil
+}
+
+// IntN returns a uniformly distributed random int in the range [0, n), without modulo bias.
+//
+// Panics if n <= 0.
+func (s *Session) IntN(n int) (int, error) {
+	if n <= 0 {
+		panic("ctrdrbg: invalid argument to IntN")
+	}
+
+	// Lemire's multiply-and-reject method: draws whose low product falls below the threshold are
+	// rejected so that every result is equally likely.
+	bound := uint64(n)
+	threshold := -bound % bound
+	for {
+		x, err := s.Uint64()
+		if err != nil {
+			return 0, err
+		}
+		hi, lo := bits.Mul64(x, bound)
+		if lo >= threshold {
+			return int(hi), nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_Session_Read verifies that a session serves every read from the one instance it holds.
+func Test_Session_Read(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(4), WithShardStrategy(ShardStrategyRoundRobin))
+	is.NoError(err)
+
+	s, err := r.Acquire()
+	is.NoError(err)
+	defer s.Release()
+	d := s.d
+	start := d.requests
+
+	buf := make([]byte, 32)
+	for i := 0; i < 8; i++ {
+		n, err := s.Read(buf)
+		is.NoError(err)
+		is.Equal(32, n)
+	}
+	is.Equal(start+8, d.requests)
+	n, err := s.ReadWithAdditionalInput(buf, []byte("context"))
+	is.NoError(err)
+	is.Equal(32, n)
+	is.Same(d, s.d, "the session should hold one instance until released")
+
+	n, err = s.Read(nil)
+	is.NoError(err)
+	is.Zero(n)
+}
+
+// Test_Session_ZeroAlloc verifies that acquiring, reading from, and releasing a session does not allocate.
+func Test_Session_ZeroAlloc(t *testing.T) {
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(1))
+	is.NoError(err)
+	defer r.Close()
+
+	buf := make([]byte, 32)
+	allocs := testing.AllocsPerRun(1000, func() {
+		s, err := r.Acquire()
+		is.NoError(err)
+		_, err = s.Read(buf)
+		is.NoError(err)
+		s.Release()
+	})
+	is.Zero(allocs, "Acquire, Read, and Release should not allocate")
+}
+
+// Test_Session_Helpers verifies the typed helpers.
+func Test_Session_Helpers(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader()
+	is.NoError(err)
+	s, err := r.Acquire()
+	is.NoError(err)
+	defer s.Release()
+
+	b, err := s.Bytes(48)
+	is.NoError(err)
+	is.Len(b, 48)
+	_, err = s.Bytes(MaxBytesPerRequest + 1)
+	is.ErrorIs(err, ErrRequestTooLarge)
+
+	a, err := s.Uint64()
+	is.NoError(err)
+	c, err := s.Uint64()
+	is.NoError(err)
+	is.NotEqual(a, c)
+
+	_, err = s.Uint32()
+	is.NoError(err)
+
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		v, err := s.IntN(6)
+		is.NoError(err)
+		is.GreaterOrEqual(v, 0)
+		is.Less(v, 6)
+		seen[v] = true
+	}
+	is.Len(seen, 6, "every value in range should occur")
+
+	v, err := s.IntN(1)
+	is.NoError(err)
+	is.Zero(v)
+	is.Panics(func() { _, _ = s.IntN(0) })
+}
+
+// Test_Session_Release verifies that a released session refuses use and that Release is idempotent.
+func Test_Session_Release(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader()
+	is.NoError(err)
+	s, err := r.Acquire()
+	is.NoError(err)
+	s.Release()
+	s.Release()
+
+	_, err = s.Read(make([]byte, 16))
+	is.ErrorIs(err, ErrSessionReleased)
+	_, err = s.Read(nil)
+	is.ErrorIs(err, ErrSessionReleased, "use after release should be detected even for empty reads")
+	_, err = s.ReadWithAdditionalInput(make([]byte, 16), nil)
+	is.ErrorIs(err, ErrSessionReleased)
+	_, err = s.Bytes(16)
+	is.ErrorIs(err, ErrSessionReleased)
+	_, err = s.Uint32()
+	is.ErrorIs(err, ErrSessionReleased)
+	_, err = s.Uint64()
+	is.ErrorIs(err, ErrSessionReleased)
+	_, err = s.IntN(10)
+	is.ErrorIs(err, ErrSessionReleased)
+
+	var zero Session
+	_, err = zero.Read(make([]byte, 16))
+	is.ErrorIs(err, ErrSessionReleased, "a zero Session should behave as released")
+	zero.Release()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"fmt"
+)
+
+// ShardStrategy selects how a Reader chooses the shard (pool of DRBG instances) that serves each request.
+type ShardStrategy int
+
+const (
+	// ShardStrategyAffinity keeps each processor (P) on a stable shard, improving cache locality and
+	// avoiding a random draw per request. This is the default.
+	//
+	// The shard hint is held in a per-P sync.Pool, so goroutines running on the same P reuse the same
+	// shard. Hints are assigned round-robin and may be reassigned after a garbage collection.
+	ShardStrategyAffinity ShardStrategy = iota
+
+	// ShardStrategyRandom picks a pseudo-random shard for every request using math/rand/v2.
+	ShardStrategyRandom
+
+	// ShardStrategyRoundRobin cycles through shards using a shared atomic counter.
+	ShardStrategyRoundRobin
+)
+
+// String returns a human-readable name for the shard strategy.
+func (s ShardStrategy) String() string {
+	switch s {
+	case ShardStrategyAffinity:
+		return "affinity"
+	case ShardStrategyRandom:
+		return "random"
+	case ShardStrategyRoundRobin:
+		return "round-robin"
+	default:
+		return fmt.Sprintf("ShardStrategy(%d)", int(s))
+	}
+}
+
+// shardHint records the shard assigned to the processor (P) whose sync.Pool cache holds it.
+type shardHint struct {
+	idx int
+}
+
+// shard returns the index of the shard that serves the next request, according to the reader's strategy.
+func (r *reader) shard() int {
+	n := len(r.pools)
+	if n <= 1 {
+		return 0
+	}
+
+	switch r.strategy {
+	case ShardStrategyRandom:
+		return shardIndex(n)
+	case ShardStrategyRoundRobin:
+		return r.nextShard(n)
+	default:
+		// sync.Pool keeps a per-P cache, so the hint obtained here is (almost always) the one
+		// previously put back by this P.
+		h, _ := r.hints.Get().(*shardHint)
+		if h == nil {
+			h = &shardHint{idx: r.nextShard(n)}
+		}
+		idx := h.idx
+		r.hints.Put(h)
+		return idx
+	}
+}
+
+// nextShard returns the next shard index in round-robin order.
+func (r *reader) nextShard(n int) int {
+	return int((r.next.Add(1) - 1) % uint64(n))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_ShardStrategy_Range verifies that every strategy returns a valid shard index and serves reads.
+func Test_ShardStrategy_Range(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range []ShardStrategy{ShardStrategyAffinity, ShardStrategyRandom, ShardStrategyRoundRobin} {
+		strategy := strategy
+		t.Run(strategy.String(), func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			rdr, err := NewReader(WithShards(4), WithShardStrategy(strategy))
+			is.NoError(err)
+			r := rdr.(*reader)
+			is.Equal(strategy, r.strategy)
+
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 100; i++ {
+						idx := r.shard()
+						is.GreaterOrEqual(idx, 0)
+						is.Less(idx, 4)
+					}
+				}()
+			}
+			wg.Wait()
+
+			buf := make([]byte, 32)
+			_, err = rdr.Read(buf)
+			is.NoError(err)
+			_, err = rdr.ReadWithAdditionalInput(buf, nil)
+			is.NoError(err)
+		})
+	}
+}
+
+// Test_ShardStrategy_RoundRobin verifies that round-robin selection cycles through every shard in order.
+func Test_ShardStrategy_RoundRobin(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r := &reader{pools: make([]*instancePool, 3), strategy: ShardStrategyRoundRobin}
+	var got []int
+	for i := 0; i < 7; i++ {
+		got = append(got, r.shard())
+	}
+	is.Equal([]int{0, 1, 2, 0, 1, 2, 0}, got)
+}
+
+// Test_ShardStrategy_Affinity verifies that affinity hints are assigned round-robin and reused.
+func Test_ShardStrategy_Affinity(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	// The zero-value reader uses affinity selection.
+	r := &reader{pools: make([]*instancePool, 4)}
+	first := r.shard()
+	is.Equal(0, first, "the first hint should be assigned shard 0")
+
+	// A hint is only created when the per-P cache is empty, so the shared counter advances
+	// far less often than once per request.
+	for i := 0; i < 1000; i++ {
+		idx := r.shard()
+		is.GreaterOrEqual(idx, 0)
+		is.Less(idx, 4)
+	}
+	is.Less(r.next.Load(), uint64(1000), "affinity should reuse per-P hints")
+
+	// A single shard never consults the hint pool.
+	single := &reader{pools: make([]*instancePool, 1)}
+	is.Equal(0, single.shard())
+	is.Zero(single.next.Load())
+}
+
+// Test_ShardStrategy_Invalid verifies that NewReader rejects an unknown strategy.
+func Test_ShardStrategy_Invalid(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	_, err := NewReader(WithShardStrategy(ShardStrategy(42)))
+	is.Error(err)
+	is.Equal("ShardStrategy(42)", ShardStrategy(42).String())
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of a Reader's activity counters, returned by Interface.Stats.
+//
+// Counters are cumulative from the construction of the Reader and are never reset, including by Close.
+// Each counter is read atomically, but the snapshot as a whole is not: requests running concurrently with
+// Stats may be partially reflected.
+type Stats struct {
+	// Shards holds the counters of each shard, indexed by shard.
+	Shards []ShardStats
+
+	// Total is the sum of Shards.
+	Total ShardStats
+}
+
+// ShardStats holds the activity counters of one shard (or, in Stats.Total, of a whole Reader).
+type ShardStats struct {
+	// InstancesCreated is the number of DRBG instances instantiated.
+	InstancesCreated uint64
+
+	// Requests is the number of generate requests (Read and ReadWithAdditionalInput calls, including
+	// those made through a Session) that returned output.
+	Requests uint64
+
+	// BytesGenerated is the number of output bytes returned to callers.
+	BytesGenerated uint64
+
+	// Reseeds counts successful reseeds by cause.
+	Reseeds ReseedStats
+
+	// Rekeys is the number of successful key rotations.
+	Rekeys uint64
+
+	// RekeyLatency is the distribution of the time taken by successful key rotations, from the start of
+	// seed derivation (including any retries and backoff) until the new seed was ready to install.
+	RekeyLatency LatencyHistogram
+
+	// RekeyFailures is the number of key rotations that exhausted MaxRekeyAttempts.
+	RekeyFailures uint64
+
+	// HealthTestFailures is the number of continuous health test failures.
+	HealthTestFailures uint64
+
+	// EntropyBytes is the number of entropy input bytes consumed from the entropy source (or the shard's
+	// prefetch buffer) for instantiation, reseeding, key rotation, and RBG3(XOR) output.
+	EntropyBytes uint64
+}
+
+// ReseedStats counts successful reseeds by cause.
+type ReseedStats struct {
+	// Interval counts reseeds triggered by ReseedInterval.
+	Interval uint64
+
+	// RequestCount counts reseeds triggered by ReseedRequests.
+	RequestCount uint64
+
+	// PredictionResistance counts reseeds performed before each request with PredictionResistance enabled.
+	PredictionResistance uint64
+
+	// Fork counts reseeds triggered by detection of a process fork.
+	Fork uint64
+
+	// Generation counts reseeds triggered by a VM generation change (see WithGenerationIDProvider).
+	Generation uint64
+
+	// Manual counts reseeds requested with Reseed. Every instance applies each Reseed, so one call
+	// is counted once per instance.
+	Manual uint64
+
+	// AdditionalInput counts reseeds performed by ReadWithAdditionalInput to mix in its additional input.
+	AdditionalInput uint64
+
+	// RekeyFailure counts reseeds performed in place of a failed key rotation (RekeyFailureReseed).
+	RekeyFailure uint64
+}
+
+// Total returns the number of reseeds across all causes.
+func (s ReseedStats) Total() uint64 {
+	return s.Interval + s.RequestCount + s.PredictionResistance + s.Fork + s.Generation +
+		s.Manual + s.AdditionalInput + s.RekeyFailure
+}
+
+// add adds the counters of o to s.
+func (s *ShardStats) add(o *ShardStats) {
+	s.InstancesCreated += o.InstancesCreated
+	s.Requests += o.Requests
+	s.BytesGenerated += o.BytesGenerated
+	s.Reseeds.Interval += o.Reseeds.Interval
+	s.Reseeds.RequestCount += o.Reseeds.RequestCount
+	s.Reseeds.PredictionResistance += o.Reseeds.PredictionResistance
+	s.Reseeds.Fork += o.Reseeds.Fork
+	s.Reseeds.Generation += o.Reseeds.Generation
+	s.Reseeds.Manual += o.Reseeds.Manual
+	s.Reseeds.AdditionalInput += o.Reseeds.AdditionalInput
+	s.Reseeds.RekeyFailure += o.Reseeds.RekeyFailure
+	s.Rekeys += o.Rekeys
+	s.RekeyLatency.add(&o.RekeyLatency)
+	s.RekeyFailures += o.RekeyFailures
+	s.HealthTestFailures += o.HealthTestFailures
+	s.EntropyBytes += o.EntropyBytes
+}
+
+// latencyBounds are the upper bounds of the LatencyHistogram buckets. Seed derivation normally takes tens
+// of microseconds; the upper buckets capture slow entropy sources and rekey backoff.
+var latencyBounds = [...]time.Duration{
+	10 * time.Microsecond,
+	25 * time.Microsecond,
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+// LatencyBounds returns the upper bounds of the LatencyHistogram buckets, in increasing order.
+func LatencyBounds() []time.Duration {
+	return latencyBounds[:]
+}
+
+// LatencyHistogram is a distribution of durations over the buckets returned by LatencyBounds.
+type LatencyHistogram struct {
+	// Buckets holds the number of observations in each bucket: Buckets[i] counts durations greater than
+	// LatencyBounds()[i-1] and at most LatencyBounds()[i]. The last bucket counts durations above every bound.
+	Buckets [len(latencyBounds) + 1]uint64
+
+	// Sum is the total of all observed durations.
+	Sum time.Duration
+}
+
+// Count returns the number of observations.
+func (h *LatencyHistogram) Count() uint64 {
+	var n uint64
+	for _, c := range h.Buckets {
+		n += c
+	}
+	return n
+}
+
+// add adds the observations of o to h.
+func (h *LatencyHistogram) add(o *LatencyHistogram) {
+	for i, c := range o.Buckets {
+		h.Buckets[i] += c
+	}
+	h.Sum += o.Sum
+}
+
+// latencyCounts is the concurrently readable form of a LatencyHistogram.
+type latencyCounts struct {
+	buckets [len(latencyBounds) + 1]atomic.Uint64
+	sum     atomic.Int64
+}
+
+// observe records one duration.
+func (c *latencyCounts) observe(d time.Duration) {
+	i := 0
+	for i < len(latencyBounds) && d > latencyBounds[i] {
+		i++
+	}
+	c.buckets[i].Add(1)
+	c.sum.Add(int64(d))
+}
+
+// addTo adds the recorded durations to h.
+func (c *latencyCounts) addTo(h *LatencyHistogram) {
+	for i := range c.buckets {
+		h.Buckets[i] += c.buckets[i].Load()
+	}
+	h.Sum += time.Duration(c.sum.Load())
+}
+
+// ReseedCause identifies why an instance reseeded. It is reported to Hooks.OnReseed and in log events,
+// and Stats counts reseeds by cause (see ReseedStats).
+type ReseedCause int
+
+const (
+	// ReseedCauseInterval is a reseed triggered by ReseedInterval.
+	ReseedCauseInterval ReseedCause = iota
+
+	// ReseedCauseRequestCount is a reseed triggered by ReseedRequests.
+	ReseedCauseRequestCount
+
+	// ReseedCausePredictionResistance is the reseed before each request with PredictionResistance enabled.
+	ReseedCausePredictionResistance
+
+	// ReseedCauseFork is a reseed triggered by detection of a process fork.
+	ReseedCauseFork
+
+	// ReseedCauseGeneration is a reseed triggered by a VM generation change.
+	ReseedCauseGeneration
+
+	// ReseedCauseManual is a reseed requested with Reseed.
+	ReseedCauseManual
+
+	// ReseedCauseAdditionalInput is a reseed performed by ReadWithAdditionalInput to mix in its additional input.
+	ReseedCauseAdditionalInput
+
+	// ReseedCauseRekeyFailure is a reseed performed in place of a failed key rotation (RekeyFailureReseed).
+	ReseedCauseRekeyFailure
+
+	// numReseedCauses is the number of reseed causes.
+	numReseedCauses
+)
+
+// String returns the name of the reseed cause, as used in log events and metric labels.
+func (c ReseedCause) String() string {
+	switch c {
+	case ReseedCauseInterval:
+		return "interval"
+	case ReseedCauseRequestCount:
+		return "request_count"
+	case ReseedCausePredictionResistance:
+		return "prediction_resistance"
+	case ReseedCauseFork:
+		return "fork"
+	case ReseedCauseGeneration:
+		return "generation"
+	case ReseedCauseManual:
+		return "manual"
+	case ReseedCauseAdditionalInput:
+		return "additional_input"
+	case ReseedCauseRekeyFailure:
+		return "rekey_failure"
+	default:
+		return "unknown"
+	}
+}
+
+// instanceStats holds the counters of one DRBG instance.
+//
+// Counters are only written by the instance's owner, except entropyBytes, which the background rekey
+// goroutine also updates. They are atomic so that Stats can read them while the instance is in use; an
+// uncontended atomic add does not allocate and is the only cost on the read path.
+type instanceStats struct {
+	requests           atomic.Uint64
+	bytes              atomic.Uint64
+	reseeds            [numReseedCauses]atomic.Uint64
+	rekeys             atomic.Uint64
+	rekeyLatency       latencyCounts
+	rekeyFailures      atomic.Uint64
+	healthTestFailures atomic.Uint64
+	entropyBytes       atomic.Uint64
+}
+
+// generated records a generate request that returned n bytes.
+func (s *instanceStats) generated(n int) {
+	s.requests.Add(1)
+	s.bytes.Add(uint64(n))
+}
+
+// addTo adds the instance's counters to out.
+func (s *instanceStats) addTo(out *ShardStats) {
+	out.Requests += s.requests.Load()
+	out.BytesGenerated += s.bytes.Load()
+	out.Reseeds.Interval += s.reseeds[ReseedCauseInterval].Load()
+	out.Reseeds.RequestCount += s.reseeds[ReseedCauseRequestCount].Load()
+	out.Reseeds.PredictionResistance += s.reseeds[ReseedCausePredictionResistance].Load()
+	out.Reseeds.Fork += s.reseeds[ReseedCauseFork].Load()
+	out.Reseeds.Generation += s.reseeds[ReseedCauseGeneration].Load()
+	out.Reseeds.Manual += s.reseeds[ReseedCauseManual].Load()
+	out.Reseeds.AdditionalInput += s.reseeds[ReseedCauseAdditionalInput].Load()
+	out.Reseeds.RekeyFailure += s.reseeds[ReseedCauseRekeyFailure].Load()
+	out.Rekeys += s.rekeys.Load()
+	s.rekeyLatency.addTo(&out.RekeyLatency)
+	out.RekeyFailures += s.rekeyFailures.Load()
+	out.HealthTestFailures += s.healthTestFailures.Load()
+	out.EntropyBytes += s.entropyBytes.Load()
+}
+
+// stats returns the counters of the pool's shard, summed over every instance it has created. It holds p.mu
+// so that an instance being unregistered is counted either in live or in retired, never both or neither.
+func (p *instancePool) stats() ShardStats {
+	p.mu.Lock()
+	s := p.retired
+	for _, d := range p.registered() {
+		d.stats.addTo(&s)
+	}
+	p.mu.Unlock()
+	s.InstancesCreated = p.created.Load()
+	return s
+}
+
+// Stats returns a snapshot of the reader's activity counters, per shard and in total.
+//
+// Stats holds each shard's pool lock while summing its instances, so that counters of instances being
+// retired are counted exactly once and totals never decrease between snapshots. The read path does not
+// take that lock, so a request waits for Stats only when it creates or retires an instance or applies a
+// pending Reseed. Stats is safe to call concurrently with any other method, including after Close.
+func (r *reader) Stats() Stats {
+	s := Stats{Shards: make([]ShardStats, len(r.pools))}
+	for i, pool := range r.pools {
+		s.Shards[i] = pool.stats()
+		s.Total.add(&s.Shards[i])
+	}
+	return s
+}
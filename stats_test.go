@@ -0,0 +1,138 @@
+// Copyright (c) 2024-2026 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package ctrdrbg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_Reader_Stats verifies the request, byte, reseed, and entropy counters of a single-shard Reader.
+func Test_Reader_Stats(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(1), WithReseedRequests(4), WithReseedInterval(0))
+	is.NoError(err)
+	defer r.Close()
+
+	buf := make([]byte, 32)
+	for i := 0; i < 10; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+	is.NoError(r.Reseed(nil))
+	_, err = r.ReadWithAdditionalInput(buf, []byte("context"))
+	is.NoError(err)
+
+	s := r.Stats()
+	is.Len(s.Shards, 1)
+	is.Equal(s.Shards[0], s.Total)
+
+	total := s.Total
+	is.Equal(uint64(1), total.InstancesCreated)
+	is.Equal(uint64(11), total.Requests)
+	is.Equal(uint64(11*32), total.BytesGenerated)
+	is.Equal(ReseedStats{RequestCount: 2, Manual: 1, AdditionalInput: 1}, total.Reseeds)
+	is.Equal(uint64(4), total.Reseeds.Total())
+
+	// One seed for instantiation and one per reseed.
+	seedLen := uint64(r.Config().KeySize) + 16
+	is.Equal(5*seedLen, total.EntropyBytes)
+
+	// Counters survive Close.
+	is.NoError(r.Close())
+	is.Equal(s, r.Stats())
+}
+
+// Test_Reader_Stats_Shards verifies that counters are reported per shard and summed in Total.
+func Test_Reader_Stats_Shards(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(2), WithShardStrategy(ShardStrategyRoundRobin))
+	is.NoError(err)
+	defer r.Close()
+
+	buf := make([]byte, 16)
+	for i := 0; i < 6; i++ {
+		_, err = r.Read(buf)
+		is.NoError(err)
+	}
+
+	s := r.Stats()
+	is.Len(s.Shards, 2)
+	for i, shard := range s.Shards {
+		is.Equal(uint64(3), shard.Requests, "shard %d", i)
+		is.Equal(uint64(48), shard.BytesGenerated, "shard %d", i)
+		is.GreaterOrEqual(shard.InstancesCreated, uint64(1), "shard %d", i)
+	}
+	is.Equal(uint64(6), s.Total.Requests)
+	is.Equal(s.Shards[0].InstancesCreated+s.Shards[1].InstancesCreated, s.Total.InstancesCreated)
+	is.Equal(s.Shards[0].EntropyBytes+s.Shards[1].EntropyBytes, s.Total.EntropyBytes)
+}
+
+// Test_Stats_Rekey verifies the rekey, rekey failure, and fallback reseed counters.
+func Test_Stats_Rekey(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	d, src, _ := newFailingRekeyDRBG(t, RekeyModeSync, RekeyFailureReseed)
+	buf := make([]byte, 64)
+	_, err := d.Read(buf)
+	is.NoError(err)
+
+	var s ShardStats
+	d.stats.addTo(&s)
+	is.Equal(uint64(1), s.RekeyFailures)
+	is.Zero(s.Rekeys)
+
+	// The recovered source resolves the failure with a reseed, and the next rotation succeeds.
+	src.fail.Store(false)
+	_, err = d.Read(buf)
+	is.NoError(err)
+	_, err = d.Read(buf)
+	is.NoError(err)
+
+	s = ShardStats{}
+	d.stats.addTo(&s)
+	is.Equal(uint64(1), s.Reseeds.RekeyFailure)
+	is.GreaterOrEqual(s.Rekeys, uint64(1))
+	is.Equal(s.Rekeys, s.RekeyLatency.Count(), "every rotation should record its latency")
+	is.Equal(uint64(3), s.Requests)
+}
+
+// Test_Stats_HealthTest verifies that continuous health test failures are counted.
+func Test_Stats_HealthTest(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cfg := DefaultConfig()
+	cfg.ContinuousHealthTest = true
+	d, err := newDRBG(&cfg)
+	is.NoError(err)
+
+	block := make([]byte, 16)
+	is.NoError(d.continuousHealthTest(block))
+	is.ErrorIs(d.continuousHealthTest(block), ErrHealthTestFailed)
+	is.Equal(uint64(1), d.stats.healthTestFailures.Load())
+}
+
+// Test_Reader_Stats_NoAllocs verifies that the counters add no allocations to the read path.
+func Test_Reader_Stats_NoAllocs(t *testing.T) {
+	is := assert.New(t)
+
+	r, err := NewReader(WithShards(1))
+	is.NoError(err)
+	defer r.Close()
+
+	buf := make([]byte, 32)
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = r.Read(buf)
+	})
+	is.Zero(allocs)
+}